@@ -0,0 +1,87 @@
+package filejez
+
+import (
+	"errors"
+	"io/fs"
+
+	"github.com/dengrandpa/jez/internal/multierr"
+)
+
+var (
+	// ErrNotExist 文件或目录不存在，等同于 fs.ErrNotExist
+	ErrNotExist = fs.ErrNotExist
+
+	// ErrExist 文件或目录已存在，等同于 fs.ErrExist
+	ErrExist = fs.ErrExist
+
+	// ErrPermission 没有权限，等同于 fs.ErrPermission
+	ErrPermission = fs.ErrPermission
+
+	// ErrFound FindFileWalk、FindFileWalkFilter 找到目标后用于提前结束 filepath.WalkDir，不会返回给调用方；
+	// 自行调用 filepath.WalkDir 时，也可以在 fs.WalkDirFunc 中返回该错误提前结束遍历，再通过 errors.Is 判断
+	ErrFound = errors.New("filejez: found")
+)
+
+// PathError 记录失败的操作、路径及原因，等同于 fs.PathError
+type PathError = fs.PathError
+
+// MultiError BestEffort 系列函数的聚合错误，记录每个失败的路径及其原因，其余路径已正常处理
+//
+// errors.Is、errors.As 会检查每个路径的错误，如 errors.Is(err, ErrPermission) 可以判断是否有因权限不足失败的路径。
+type MultiError struct {
+	Errors []*PathError
+}
+
+// 将 err 转换为 *PathError，如果 err 本身就是同一路径的 *PathError，则直接返回
+func newPathError(op, path string, err error) *PathError {
+	var pe *PathError
+	if errors.As(err, &pe) && pe.Path == path {
+		return pe
+	}
+	return &PathError{Op: op, Path: path, Err: err}
+}
+
+// Error 返回失败路径的数量，之后每行一个失败的操作、路径及原因
+func (e *MultiError) Error() string {
+	return multierr.Format("filejez", "error(s)", e.Errors)
+}
+
+// Paths 返回所有失败的路径
+func (e *MultiError) Paths() []string {
+	paths := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		paths = append(paths, err.Path)
+	}
+	return paths
+}
+
+// Is 判断是否有任意一个路径的错误匹配 target
+func (e *MultiError) Is(target error) bool {
+	return multierr.Is(e.Errors, target)
+}
+
+// As 查找第一个可以赋值给 target 的路径错误
+func (e *MultiError) As(target any) bool {
+	return multierr.As(e.Errors, target)
+}
+
+// 如果没有错误，返回 nil，否则返回 *MultiError
+func (e *MultiError) errOrNil() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	return e
+}
+
+// 对每个路径调用 fn，收集所有失败的路径及原因
+func bestEffort(op string, paths []string, fn func(path string) error) error {
+	me := new(MultiError)
+
+	for _, path := range paths {
+		if err := fn(path); err != nil {
+			me.Errors = append(me.Errors, newPathError(op, path, err))
+		}
+	}
+
+	return me.errOrNil()
+}
@@ -0,0 +1,49 @@
+package filejez
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiError(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	me := &MultiError{Errors: []*PathError{
+		{Op: "remove", Path: "a", Err: fs.ErrNotExist},
+		{Op: "remove", Path: "b", Err: fs.ErrPermission},
+	}}
+
+	ass.Equal([]string{"a", "b"}, me.Paths())
+	ass.Equal("filejez: 2 error(s) occurred:\n\tremove a: file does not exist\n\tremove b: permission denied", me.Error())
+
+	var err error = me
+
+	ass.True(errors.Is(err, ErrNotExist))
+	ass.True(errors.Is(err, ErrPermission))
+	ass.False(errors.Is(err, ErrExist))
+
+	var pe *PathError
+	ass.True(errors.As(err, &pe))
+	ass.Equal("a", pe.Path)
+
+	ass.Nil(new(MultiError).errOrNil())
+}
+
+func Test_newPathError(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	pe := &PathError{Op: "open", Path: "a", Err: fs.ErrNotExist}
+
+	ass.Same(pe, newPathError("remove", "a", pe))
+
+	ass.Equal(&PathError{Op: "mkdir", Path: "a/b", Err: pe}, newPathError("mkdir", "a/b", pe))
+
+	err := errors.New("err")
+
+	ass.Equal(&PathError{Op: "remove", Path: "b", Err: err}, newPathError("remove", "b", err))
+}
@@ -4,13 +4,62 @@ package filejez
 import (
 	"archive/zip"
 	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
 	"errors"
 	"io"
 	"os"
 	"path/filepath"
 )
 
-var walkFind = errors.New("find")
+// gzip 文件头的魔数
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// readCloser 组合 reader 与需要关闭的资源
+type readCloser struct {
+	io.Reader
+	closers []io.Closer
+}
+
+// Close 按逆序关闭所有资源
+func (r *readCloser) Close() error {
+	var err error
+	for i := len(r.closers) - 1; i >= 0; i-- {
+		if e := r.closers[i].Close(); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// openReader 打开文件，如果文件是 gzip 格式（通过魔数判断），则返回解压后的 reader，使用完毕后需要关闭
+func openReader(filePath string) (io.ReadCloser, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	br := bufio.NewReader(f)
+
+	magic, err := br.Peek(len(gzipMagic))
+	if err != nil && !errors.Is(err, io.EOF) {
+		_ = f.Close()
+		return nil, err
+	}
+
+	if !bytes.Equal(magic, gzipMagic) {
+		return &readCloser{Reader: br, closers: []io.Closer{f}}, nil
+	}
+
+	gr, err := gzip.NewReader(br)
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	return &readCloser{Reader: gr, closers: []io.Closer{f, gr}}, nil
+}
 
 // 等同于 os.ReadDir， 删除了排序
 func osReadDir(dirPath string) ([]os.DirEntry, error) {
@@ -108,41 +157,75 @@ func OsCreate(filePath string) (*os.File, error) {
 	return os.Create(filePath)
 }
 
+// 创建文件，如果文件已存在，则忽略
+func createFile(filePath string) error {
+	file, err := OsCreate(filePath)
+	if err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// 创建文件，如果文件已存在，则覆盖
+func overwriteFile(filePath string) error {
+	file, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0666)
+	if err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// 创建目录，包含子目录
+func createDir(dirPath string) error {
+	return os.MkdirAll(dirPath, os.ModePerm)
+}
+
 // CreateFiles 创建文件，如果文件已存在，则忽略
 func CreateFiles(filePaths ...string) error {
 	for _, filePath := range filePaths {
-		file, err := OsCreate(filePath)
-		if err != nil {
+		if err := createFile(filePath); err != nil {
 			return err
 		}
-		_ = file.Close()
 	}
 	return nil
 }
 
+// CreateFilesBestEffort 创建文件，如果文件已存在，则忽略，遇到错误不会停止，返回的 *MultiError 中包含所有失败的路径及原因
+func CreateFilesBestEffort(filePaths ...string) error {
+	return bestEffort("create", filePaths, createFile)
+}
+
 // OverwriteFiles 创建文件，如果文件已存在，则覆盖
 func OverwriteFiles(filePaths ...string) error {
 	for _, filePath := range filePaths {
-		file, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0666)
-		if err != nil {
+		if err := overwriteFile(filePath); err != nil {
 			return err
 		}
-		_ = file.Close()
 	}
 
 	return nil
 }
 
+// OverwriteFilesBestEffort 创建文件，如果文件已存在，则覆盖，遇到错误不会停止，返回的 *MultiError 中包含所有失败的路径及原因
+func OverwriteFilesBestEffort(filePaths ...string) error {
+	return bestEffort("overwrite", filePaths, overwriteFile)
+}
+
 // CreateDirs 创建目录，包含子目录，如果目录已存在，则忽略
 func CreateDirs(dirPaths ...string) error {
 	for _, dirPath := range dirPaths {
-		if err := os.MkdirAll(dirPath, os.ModePerm); err != nil {
+		if err := createDir(dirPath); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// CreateDirsBestEffort 创建目录，包含子目录，如果目录已存在，则忽略，遇到错误不会停止，返回的 *MultiError 中包含所有失败的路径及原因
+func CreateDirsBestEffort(dirPaths ...string) error {
+	return bestEffort("mkdir", dirPaths, createDir)
+}
+
 // CreateFilesWithDirs 创建文件，如果文件已存在，则忽略，同时创建目录，包含子目录
 func CreateFilesWithDirs(filePaths ...string) error {
 	for _, filePath := range filePaths {
@@ -176,22 +259,47 @@ func CreateFileWithData(filePath, data string) error {
 	return err
 }
 
-// CopyFile 拷贝文件
+// CopyFile 拷贝文件，dst 不存在时使用 src 的权限（受 umask 影响），已存在时保留 dst 原有的权限，写入方式与 CopyFileContext 相同
 func CopyFile(src, dst string) error {
+	return CopyFileContext(context.Background(), src, dst, nil)
+}
+
+// CopyFileContext 拷贝文件，ctx 取消时停止拷贝并返回 ctx.Err()，progress 为可选的进度回调函数，可以为 nil。
+//
+// 先写入临时文件，成功后再重命名为 dst，失败或取消时不会留下写了一半的文件，重命名会替换原有的 dst，原有的属主和扩展属性不会保留。
+// dst 已存在且不是普通文件（如设备文件）或有多个硬链接时，直接写入 dst，不会重命名。
+// dst 不存在时使用 src 的权限（受 umask 影响），已存在时保留 dst 原有的权限，dst 为符号链接时替换其指向的文件。
+func CopyFileContext(ctx context.Context, src, dst string, progress ProgressFunc) error {
 	srcFile, err := os.Open(src)
 	if err != nil {
 		return err
 	}
 	defer srcFile.Close()
 
-	dstFile, err := OsCreate(dst)
+	info, err := srcFile.Stat()
 	if err != nil {
 		return err
 	}
-	defer dstFile.Close()
 
-	_, err = io.Copy(dstFile, srcFile)
-	return err
+	tracker := newProgressTracker(ctx, progress)
+	tracker.p.FilesTotal = 1
+	tracker.p.BytesTotal = info.Size()
+
+	if err = tracker.start(src); err != nil {
+		return err
+	}
+
+	err = writeFileAtomic(dst, info.Mode().Perm(), func(dstFile *os.File) error {
+		_, err := tracker.copy(dstFile, srcFile)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	tracker.done()
+
+	return nil
 }
 
 // FindFileWalk 遍历目录、子目录，查找文件
@@ -204,13 +312,13 @@ func FindFileWalk(dirPath, filename string) (bool, error) {
 		}
 
 		if !entry.IsDir() && entry.Name() == filename {
-			return walkFind
+			return ErrFound
 		}
 
 		return nil
 	})
 
-	if err != nil && errors.Is(err, walkFind) {
+	if err != nil && errors.Is(err, ErrFound) {
 		return true, nil
 	}
 
@@ -227,13 +335,13 @@ func FindFileWalkFilter(dirPath string, iteratee func(path string, entry os.DirE
 		}
 
 		if !entry.IsDir() && iteratee(path, entry) {
-			return walkFind
+			return ErrFound
 		}
 
 		return nil
 	})
 
-	if err != nil && errors.Is(err, walkFind) {
+	if err != nil && errors.Is(err, ErrFound) {
 		return true, nil
 	}
 
@@ -359,6 +467,16 @@ func DeleteFiles(filePaths ...string) error {
 	return nil
 }
 
+// DeleteFilesContext 删除文件，ctx 取消时停止删除并返回 ctx.Err()，progress 为可选的进度回调函数，可以为 nil
+func DeleteFilesContext(ctx context.Context, progress ProgressFunc, filePaths ...string) error {
+	return deleteEach(ctx, progress, filePaths, os.Remove)
+}
+
+// DeleteFilesBestEffort 删除文件，遇到错误不会停止，返回的 *MultiError 中包含所有失败的路径及原因
+func DeleteFilesBestEffort(filePaths ...string) error {
+	return bestEffort("remove", filePaths, os.Remove)
+}
+
 // DeleteDirs 删除目录
 func DeleteDirs(dirPaths ...string) error {
 	for _, dirPath := range dirPaths {
@@ -369,6 +487,36 @@ func DeleteDirs(dirPaths ...string) error {
 	return nil
 }
 
+// DeleteDirsContext 删除目录，ctx 取消时停止删除并返回 ctx.Err()，progress 为可选的进度回调函数，可以为 nil
+func DeleteDirsContext(ctx context.Context, progress ProgressFunc, dirPaths ...string) error {
+	return deleteEach(ctx, progress, dirPaths, os.RemoveAll)
+}
+
+// 对每个路径调用 remove，每次删除前检查 ctx
+func deleteEach(ctx context.Context, progress ProgressFunc, paths []string, remove func(path string) error) error {
+	tracker := newProgressTracker(ctx, progress)
+	tracker.p.FilesTotal = len(paths)
+
+	for _, path := range paths {
+		if err := tracker.start(path); err != nil {
+			return err
+		}
+
+		if err := remove(path); err != nil {
+			return err
+		}
+
+		tracker.done()
+	}
+
+	return nil
+}
+
+// DeleteDirsBestEffort 删除目录，遇到错误不会停止，返回的 *MultiError 中包含所有失败的路径及原因
+func DeleteDirsBestEffort(dirPaths ...string) error {
+	return bestEffort("removeall", dirPaths, os.RemoveAll)
+}
+
 // DeleteEmptyDirWalk 返回删除空目录，包含子目录
 //
 // 例如：
@@ -443,112 +591,187 @@ func DeleteWalkBy(
 	return true, DeleteDirs(dirPath)
 }
 
-// Zip 将目录或文件压缩为 zip 文件，如果zip已存在，则会被覆盖。
+// Zip 将目录或文件压缩为 zip 文件，如果zip已存在，则会被覆盖，写入方式与 ZipContext 相同。
 func Zip(src, dst string) error {
-	zipFile, err := OsCreate(dst)
-	if err != nil {
-		return err
-	}
-	defer zipFile.Close()
+	return zipWalk(context.Background(), src, dst, nil, nil)
+}
+
+// ZipContext 将目录或文件压缩为 zip 文件，如果zip已存在，则会被覆盖。
+//
+// 参数：
+//   - ctx: 取消时停止压缩并返回 ctx.Err()
+//   - progress: 可选参数，进度回调函数，可以为 nil
+//
+// 注意事项：
+//   - 先写入临时文件，成功后再重命名为 dst，失败或取消时不会留下写了一半的 zip 文件。
+//   - dst 已存在且不是普通文件（如设备文件）或有多个硬链接时，直接写入 dst，不会重命名，失败或取消时可能留下写了一半的内容。
+//   - 重命名会替换原有的 dst，原有的属主和扩展属性不会保留。
+func ZipContext(ctx context.Context, src, dst string, progress ProgressFunc) error {
+	return zipWalk(ctx, src, dst, nil, progress)
+}
 
-	zipWriter := zip.NewWriter(zipFile)
-	defer zipWriter.Close()
+// ZipFilter 对每个文件或目录调用 iteratee 函数，如果返回 true，则将其压缩到 zip 文件中，如果zip文件已存在，则会被覆盖。
+func ZipFilter(src, dst string, iteratee func(path string, entry os.DirEntry) bool) error {
+	return zipWalk(context.Background(), src, dst, iteratee, nil)
+}
 
-	return filepath.WalkDir(src, func(path string, info os.DirEntry, err error) error {
-		if err != nil {
-			return err
-		}
+// ZipFilterContext 对每个文件或目录调用 iteratee 函数，如果返回 true，则将其压缩到 zip 文件中，如果zip文件已存在，则会被覆盖。
+//
+// 参数：
+//   - ctx: 取消时停止压缩并返回 ctx.Err()
+//   - iteratee: 每个文件或目录只会调用一次
+//   - progress: 可选参数，进度回调函数，可以为 nil，只统计 iteratee 返回 true 的文件
+//
+// 注意事项：
+//   - 先写入临时文件，成功后再重命名为 dst，失败或取消时不会留下写了一半的 zip 文件。
+//   - dst 已存在且不是普通文件（如设备文件）或有多个硬链接时，直接写入 dst，不会重命名，失败或取消时可能留下写了一半的内容。
+//   - 重命名会替换原有的 dst，原有的属主和扩展属性不会保留。
+func ZipFilterContext(
+	ctx context.Context, src, dst string, iteratee func(path string, entry os.DirEntry) bool, progress ProgressFunc) error {
+	return zipWalk(ctx, src, dst, iteratee, progress)
+}
 
-		// 在 zip 文件中创建文件或目录
-		zipPath, err := filepath.Rel(src, path)
-		if err != nil {
-			return err
+// 遍历 src，将 iteratee 返回 true 的文件或目录压缩到 dst 中，iteratee 为 nil 时压缩所有文件和目录
+func zipWalk(
+	ctx context.Context, src, dst string, iteratee func(path string, entry os.DirEntry) bool, progress ProgressFunc) error {
+
+	tracker := newProgressTracker(ctx, progress)
+
+	// 统计总数时记录 iteratee 的结果，保证每个文件或目录只调用一次 iteratee
+	var selected map[string]bool
+
+	// 只有需要报告进度时才统计总数
+	if progress != nil {
+		if iteratee != nil {
+			selected = make(map[string]bool)
 		}
 
-		// 如果是目录，则创建目录
-		if info.IsDir() {
-			_, err = zipWriter.Create(zipPath + "/")
+		err := filepath.WalkDir(src, func(path string, entry os.DirEntry, err error) error {
 			if err != nil {
 				return err
 			}
-		} else {
-			// 如果是文件，则创建文件并将文件内容写入 zip 文件
-			var file *os.File
-			if file, err = os.Open(path); err != nil {
-				return err
+
+			if iteratee != nil {
+				selected[path] = iteratee(path, entry)
+				if !selected[path] {
+					return nil
+				}
 			}
-			defer file.Close()
 
-			var writer io.Writer
-			if writer, err = zipWriter.Create(zipPath); err != nil {
-				return err
+			if entry.IsDir() {
+				return nil
 			}
 
-			if _, err = io.Copy(writer, file); err != nil {
+			info, err := entry.Info()
+			if err != nil {
 				return err
 			}
-		}
-
-		return nil
-	})
-}
 
-// ZipFilter 对每个文件或目录调用 iteratee 函数，如果返回 true，则将其压缩到 zip 文件中，如果zip文件已存在，则会被覆盖。
-func ZipFilter(src, dst string, iteratee func(path string, entry os.DirEntry) bool) error {
-	zipFile, err := OsCreate(dst)
-	if err != nil {
-		return err
-	}
-	defer zipFile.Close()
-
-	zipWriter := zip.NewWriter(zipFile)
-	defer zipWriter.Close()
+			tracker.p.FilesTotal++
+			tracker.p.BytesTotal += info.Size()
 
-	return filepath.WalkDir(src, func(path string, info os.DirEntry, err error) error {
+			return nil
+		})
 		if err != nil {
 			return err
 		}
+	}
 
-		if !iteratee(path, info) {
-			return nil
-		}
-
-		// 在 zip 文件中创建文件或目录
-		zipPath, err := filepath.Rel(src, path)
+	return writeFileAtomic(dst, 0666, func(zipFile *os.File) error {
+		// 临时文件可能位于 src 中，需要跳过
+		tmpPath, err := filepath.Abs(zipFile.Name())
 		if err != nil {
 			return err
 		}
 
-		// 如果是文件，则创建文件并将文件内容写入 zip 文件
-		if info.IsDir() {
-			_, err = zipWriter.Create(zipPath + "/")
+		zipWriter := zip.NewWriter(zipFile)
+
+		err = filepath.WalkDir(src, func(path string, info os.DirEntry, err error) error {
 			if err != nil {
 				return err
 			}
-		} else {
+
+			// 先跳过临时文件，不会传给 iteratee
+			if absPath, _ := filepath.Abs(path); absPath == tmpPath {
+				return nil
+			}
+
+			if selected != nil {
+				if !selected[path] {
+					return nil
+				}
+			} else if iteratee != nil && !iteratee(path, info) {
+				return nil
+			}
+
+			// 在 zip 文件中创建文件或目录
+			zipPath, err := filepath.Rel(src, path)
+			if err != nil {
+				return err
+			}
+
+			// 如果是目录，则创建目录
+			if info.IsDir() {
+				_, err = zipWriter.Create(zipPath + "/")
+				return err
+			}
+
 			// 如果是文件，则创建文件并将文件内容写入 zip 文件
-			var file *os.File
-			if file, err = os.Open(path); err != nil {
+			if err = tracker.start(path); err != nil {
 				return err
 			}
-			defer file.Close()
 
 			var writer io.Writer
 			if writer, err = zipWriter.Create(zipPath); err != nil {
 				return err
 			}
 
-			if _, err = io.Copy(writer, file); err != nil {
+			if err = copyFrom(tracker, writer, path); err != nil {
 				return err
 			}
+
+			tracker.done()
+
+			return nil
+		})
+
+		if err != nil {
+			_ = zipWriter.Close()
+			return err
 		}
 
-		return nil
+		return zipWriter.Close()
 	})
 }
 
-// Unzip 解压 zip 文件到指定目录，如果目录不存在，则会被创建。
+// 打开 path 并将内容写入 dst
+func copyFrom(tracker *progressTracker, dst io.Writer, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = tracker.copy(dst, file)
+	return err
+}
+
+// Unzip 解压 zip 文件到指定目录，如果目录不存在，则会被创建，写入方式与 UnzipContext 相同。
 func Unzip(src, dst string) error {
+	return UnzipContext(context.Background(), src, dst, nil)
+}
+
+// UnzipContext 解压 zip 文件到指定目录，如果目录不存在，则会被创建。
+//
+// 参数：
+//   - ctx: 取消时停止解压并返回 ctx.Err()
+//   - progress: 可选参数，进度回调函数，可以为 nil
+//
+// 注意事项：
+//   - 失败或取消时，会删除本次新创建的文件和目录，已存在且被覆盖的文件不会被恢复。
+//   - 每个文件先写入临时文件，成功后再重命名，重命名会替换已存在的文件，原有的属主和扩展属性不会保留。
+//   - 已存在的文件不是普通文件（如设备文件）或有多个硬链接时，直接写入该文件，不会重命名。
+func UnzipContext(ctx context.Context, src, dst string, progress ProgressFunc) (err error) {
 
 	reader, err := zip.OpenReader(src)
 	if err != nil {
@@ -556,60 +779,146 @@ func Unzip(src, dst string) error {
 	}
 	defer reader.Close()
 
+	tracker := newProgressTracker(ctx, progress)
+
+	for _, file := range reader.File {
+		if !file.FileInfo().IsDir() {
+			tracker.p.FilesTotal++
+			tracker.p.BytesTotal += int64(file.UncompressedSize64)
+		}
+	}
+
+	// 本次新创建的文件和目录，按创建顺序排列
+	var created []string
+
+	defer func() {
+		if err != nil {
+			for i := len(created) - 1; i >= 0; i-- {
+				_ = os.Remove(created[i])
+			}
+		}
+	}()
+
 	// 遍历 zip 文件中的每个文件/目录
 	for _, file := range reader.File {
 		// 构建解压文件路径
 		extractedFilePath := filepath.Join(dst, file.Name)
 
 		if file.FileInfo().IsDir() {
-			if err = CreateDirs(extractedFilePath); err != nil {
-				return err
-			}
-		} else {
-			if err = CreateDirs(filepath.Dir(extractedFilePath)); err != nil {
+			if err = mkdirAllTrack(extractedFilePath, &created); err != nil {
 				return err
 			}
+			continue
+		}
 
-			var zFile io.ReadCloser
-			if zFile, err = file.Open(); err != nil {
-				return err
-			}
-			defer zFile.Close()
+		if err = mkdirAllTrack(filepath.Dir(extractedFilePath), &created); err != nil {
+			return err
+		}
 
-			var extractedFile *os.File
-			extractedFile, err = os.OpenFile(extractedFilePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, file.Mode())
-			if err != nil {
-				return err
-			}
-			defer extractedFile.Close()
+		if err = tracker.start(extractedFilePath); err != nil {
+			return err
+		}
 
-			_, err = io.Copy(extractedFile, zFile)
-			if err != nil {
-				return err
-			}
+		var exist bool
+		if exist, err = FileExists(extractedFilePath); err != nil {
+			return err
+		}
+
+		if err = unzipFile(tracker, file, extractedFilePath); err != nil {
+			return err
 		}
 
+		if !exist {
+			created = append(created, extractedFilePath)
+		}
+
+		tracker.done()
 	}
 
 	return nil
 }
 
-// ReadAll 将文件的所有内容读取为字符串。
+// 将 zip 中的单个文件解压到 dst
+func unzipFile(tracker *progressTracker, file *zip.File, dst string) error {
+	zFile, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer zFile.Close()
+
+	return writeFileAtomic(dst, file.Mode().Perm(), func(f *os.File) error {
+		_, err := tracker.copy(f, zFile)
+		return err
+	})
+}
+
+// CompressFile 使用 gzip 压缩文件，如果 dst 已存在，则会被覆盖。
+//
+// 先写入临时文件，成功后再重命名为 dst，失败时不会留下写了一半的文件。
+func CompressFile(src, dst string) error {
+	srcFile, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer srcFile.Close()
+
+	return writeFileAtomic(dst, 0666, func(dstFile *os.File) error {
+		gw := gzip.NewWriter(dstFile)
+		gw.Name = filepath.Base(src)
+
+		if _, err := io.Copy(gw, srcFile); err != nil {
+			_ = gw.Close()
+			return err
+		}
+
+		return gw.Close()
+	})
+}
+
+// DecompressFile 解压 gzip 文件，如果 dst 已存在，则会被覆盖。
+//
+// 先写入临时文件，成功后再重命名为 dst，gzip 文件损坏时不会留下写了一半的文件。
+func DecompressFile(src, dst string) error {
+	srcFile, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer srcFile.Close()
+
+	gr, err := gzip.NewReader(srcFile)
+	if err != nil {
+		return err
+	}
+	defer gr.Close()
+
+	return writeFileAtomic(dst, 0666, func(dstFile *os.File) error {
+		_, err := io.Copy(dstFile, gr)
+		return err
+	})
+}
+
+// ReadAll 将文件的所有内容读取为字符串，如果文件是 gzip 格式，则自动解压。
 func ReadAll(filePath string) (string, error) {
-	b, err := os.ReadFile(filePath)
+	f, err := openReader(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	b, err := io.ReadAll(f)
 	if err != nil {
 		return "", err
 	}
 	return string(b), nil
 }
 
-// ReadLines 读取文件的前 n 行，如果 n < 0，则读取所有行。
+// ReadLines 读取文件的前 n 行，如果 n < 0，则读取所有行，如果文件是 gzip 格式，则自动解压。
 func ReadLines(filePath string, n int) ([]string, error) {
 	if n == 0 {
 		return []string{}, nil
 	}
 
-	f, err := os.Open(filePath)
+	f, err := openReader(filePath)
 	if err != nil {
 		return nil, err
 	}
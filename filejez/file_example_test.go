@@ -1,8 +1,11 @@
 package filejez
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 )
 
 func ExampleFilterMap() {
@@ -166,6 +169,35 @@ func ExampleCreateFiles() {
 	_ = DeleteDirs(dir)
 }
 
+func ExampleCreateFilesBestEffort() {
+
+	dir := "./testdata/ExampleCreateFilesBestEffort/"
+
+	paths := []string{
+		dir + "test-file-create1.txt",
+		dir + "err/test-file-create2.txt",
+	}
+
+	_ = CreateDirs(dir)
+
+	err := CreateFilesBestEffort(paths...)
+
+	var me *MultiError
+	if errors.As(err, &me) {
+		fmt.Println(me.Paths())
+	}
+
+	fmt.Println(errors.Is(err, ErrNotExist))
+	fmt.Println(FileExists(paths[0]))
+
+	// Output:
+	// [./testdata/ExampleCreateFilesBestEffort/err/test-file-create2.txt]
+	// true
+	// true <nil>
+
+	_ = DeleteDirs(dir)
+}
+
 func ExampleCreateFileWithData() {
 
 	dir := "./testdata/TestCreateFileWithData/"
@@ -303,6 +335,28 @@ func ExampleCopyFile() {
 	_ = DeleteDirs(dir)
 }
 
+func ExampleCopyFileContext() {
+
+	dir := "./testdata/ExampleCopyFileContext/"
+
+	src := dir + "test-file-src.txt"
+	dst := dir + "test-file-dst.txt"
+
+	_ = CreateDirs(dir)
+	_ = CreateFileWithData(src, "test")
+
+	_ = CopyFileContext(context.Background(), src, dst, func(p Progress) {
+		fmt.Println(p.BytesDone, p.BytesTotal, p.FilesDone, p.FilesTotal)
+	})
+
+	// Output:
+	// 0 4 0 1
+	// 4 4 0 1
+	// 4 4 1 1
+
+	_ = DeleteDirs(dir)
+}
+
 func ExampleFindFileWalk() {
 
 	dir := "./testdata/TestFindFileWalk/"
@@ -603,18 +657,42 @@ func ExampleZip() {
 
 	dir := "./testdata/TestZip/"
 
-	_ = CreateDirs(dir)
+	_ = CreateFilesWithDirs(dir + "test-dir-walk/test-file.txt")
 
 	// target := "./test-file-zip.zip"
 
 	target := dir + "test-file-zip.zip"
 
 	// 将test-dir-walk 压缩到 ./testdata/TestZip/test-file-zip.zip
-	_ = Zip("./test-dir-walk", target)
+	_ = Zip(dir+"test-dir-walk", target)
+
+	fmt.Println(FileExists(target))
+
+	// Output:
+	// true <nil>
+
+	_ = DeleteDirs(dir)
+}
+
+func ExampleZipContext() {
+
+	dir := "./testdata/ExampleZipContext/"
+
+	_ = CreateDirs(dir + "test-dir")
+	_ = CreateFileWithData(dir+"test-dir/test-file.txt", "test")
+
+	target := dir + "test-file-zip.zip"
+
+	_ = ZipContext(context.Background(), dir+"test-dir", target, func(p Progress) {
+		if p.FilesDone == p.FilesTotal {
+			fmt.Println(p.BytesDone, p.FilesDone)
+		}
+	})
 
 	fmt.Println(FileExists(target))
 
 	// Output:
+	// 4 1
 	// true <nil>
 
 	_ = DeleteDirs(dir)
@@ -643,6 +721,34 @@ func ExampleZipFilter() {
 	_ = DeleteDirs(dir)
 }
 
+func ExampleZipFilterContext() {
+
+	dir := "./testdata/ExampleZipFilterContext/"
+
+	_ = CreateDirs(dir + "test-dir")
+	_ = CreateFileWithData(dir+"test-dir/test-file.txt", "test")
+	_ = CreateFileWithData(dir+"test-dir/test-file.log", "log")
+
+	target := dir + "test-file-zip.zip"
+
+	// 只压缩 .txt 文件
+	_ = ZipFilterContext(context.Background(), dir+"test-dir", target, func(path string, entry os.DirEntry) bool {
+		return entry.IsDir() || filepath.Ext(path) == ".txt"
+	}, func(p Progress) {
+		if p.FilesDone == p.FilesTotal {
+			fmt.Println(p.BytesDone, p.FilesDone)
+		}
+	})
+
+	fmt.Println(FileExists(target))
+
+	// Output:
+	// 4 1
+	// true <nil>
+
+	_ = DeleteDirs(dir)
+}
+
 func ExampleUnzip() {
 
 	src := "./test-file-zip.zip"
@@ -661,6 +767,53 @@ func ExampleUnzip() {
 	_ = DeleteDirs(dst)
 }
 
+func ExampleCompressFile() {
+
+	dir := "./testdata/ExampleCompressFile/"
+
+	path := dir + "test-file-data.txt"
+
+	target := dir + "test-file-data.txt.gz"
+
+	_ = CreateDirs(dir)
+	_ = CreateFileWithData(path, "test")
+
+	_ = CompressFile(path, target)
+
+	fmt.Println(FileExists(target))
+
+	// Output:
+	// true <nil>
+
+	_ = DeleteDirs(dir)
+}
+
+func ExampleDecompressFile() {
+
+	dir := "./testdata/ExampleDecompressFile/"
+
+	path := dir + "test-file-data.txt"
+
+	src := dir + "test-file-data.txt.gz"
+
+	dst := dir + "test-file-data2.txt"
+
+	_ = CreateDirs(dir)
+	_ = CreateFileWithData(path, "test")
+	_ = CompressFile(path, src)
+
+	_ = DecompressFile(src, dst)
+
+	data, _ := ReadAll(dst)
+
+	fmt.Println(data)
+
+	// Output:
+	// test
+
+	_ = DeleteDirs(dir)
+}
+
 func ExampleReadAll() {
 
 	dir := "./testdata/TestReadAll/"
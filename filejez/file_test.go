@@ -1,7 +1,11 @@
 package filejez
 
 import (
+	"archive/zip"
+	"context"
+	"errors"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -208,6 +212,36 @@ func TestCreateFiles(t *testing.T) {
 	_ = DeleteDirs(dir)
 }
 
+func TestCreateFilesBestEffort(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	dir := "./testdata/TestCreateFilesBestEffort/"
+
+	paths := []string{
+		dir + "test-file-create1.txt",
+		dir + "err/test-file-create2.txt",
+		dir + "test-file-create3.txt",
+	}
+
+	_ = CreateDirs(dir)
+
+	err := CreateFilesBestEffort(paths...)
+
+	ass.True(errors.Is(err, ErrNotExist))
+
+	var me *MultiError
+	ass.True(errors.As(err, &me))
+	ass.Equal([]string{paths[1]}, me.Paths())
+
+	ass.FileExists(paths[0])
+	ass.FileExists(paths[2])
+
+	ass.Nil(OverwriteFilesBestEffort(paths[0], paths[2]))
+
+	_ = DeleteDirs(dir)
+}
+
 func TestCreateFileWithData(t *testing.T) {
 	t.Parallel()
 	ass := assert.New(t)
@@ -272,6 +306,32 @@ func TestCreateDirs(t *testing.T) {
 	_ = DeleteDirs(dir)
 }
 
+func TestCreateDirsBestEffort(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	dir := "./testdata/TestCreateDirsBestEffort/"
+
+	file := dir + "test-file.txt"
+
+	paths := []string{
+		dir + "test-dir-create1",
+		file + "/test-dir-create2",
+	}
+
+	_ = CreateFilesWithDirs(file)
+
+	err := CreateDirsBestEffort(paths...)
+
+	var me *MultiError
+	ass.True(errors.As(err, &me))
+	ass.Equal([]string{paths[1]}, me.Paths())
+
+	ass.DirExists(paths[0])
+
+	_ = DeleteDirs(dir)
+}
+
 func TestCreateFilesWithDirs(t *testing.T) {
 	t.Parallel()
 	ass := assert.New(t)
@@ -336,6 +396,42 @@ func TestCopyFile(t *testing.T) {
 	_ = DeleteDirs(dir)
 }
 
+func TestCopyFileContext(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	dir := "./testdata/TestCopyFileContext/"
+
+	src := dir + "test-file-src.txt"
+
+	dst := dir + "test-file-dst.txt"
+
+	_ = CreateDirs(dir)
+	_ = CreateFileWithData(src, "test")
+
+	var last Progress
+
+	ass.Nil(CopyFileContext(context.Background(), src, dst, func(p Progress) {
+		last = p
+	}))
+
+	ass.Equal(Progress{BytesDone: 4, BytesTotal: 4, FilesDone: 1, FilesTotal: 1, Path: src}, last)
+
+	data, _ := ReadAll(dst)
+	ass.Equal("test", data)
+
+	// 取消后不会留下任何文件
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	ass.ErrorIs(CopyFileContext(ctx, src, dir+"test-file-cancel.txt", nil), context.Canceled)
+
+	names, _ := Filenames(dir)
+	ass.ElementsMatch([]string{"test-file-src.txt", "test-file-dst.txt"}, names)
+
+	_ = DeleteDirs(dir)
+}
+
 func TestFindFileWalk(t *testing.T) {
 	t.Parallel()
 	ass := assert.New(t)
@@ -522,6 +618,72 @@ func TestDeleteFiles(t *testing.T) {
 
 }
 
+func TestDeleteFilesBestEffort(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	dir := "./testdata/TestDeleteFilesBestEffort/"
+
+	paths := []string{
+		dir + "test-file-delete1.txt",
+		dir + "test-file-delete2.txt",
+	}
+
+	_ = CreateFilesWithDirs(paths...)
+
+	err := DeleteFilesBestEffort(paths[0], dir+"err", paths[1])
+
+	ass.True(errors.Is(err, ErrNotExist))
+
+	var me *MultiError
+	ass.True(errors.As(err, &me))
+	ass.Equal([]string{dir + "err"}, me.Paths())
+
+	for _, path := range paths {
+		ass.NoFileExists(path)
+	}
+
+	ass.Nil(DeleteFilesBestEffort(paths[:0]...))
+
+	_ = DeleteDirs(dir)
+}
+
+func TestDeleteFilesContext(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	dir := "./testdata/TestDeleteFilesContext/"
+
+	paths := []string{
+		dir + "test-file-delete1.txt",
+		dir + "test-file-delete2.txt",
+	}
+
+	_ = CreateFilesWithDirs(paths...)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// 删除第一个文件后取消
+	err := DeleteFilesContext(ctx, func(p Progress) {
+		if p.FilesDone == 1 {
+			cancel()
+		}
+	}, paths...)
+
+	ass.ErrorIs(err, context.Canceled)
+	ass.NoFileExists(paths[0])
+	ass.FileExists(paths[1])
+
+	var last Progress
+
+	ass.Nil(DeleteDirsContext(context.Background(), func(p Progress) {
+		last = p
+	}, dir))
+
+	ass.Equal(Progress{FilesDone: 1, FilesTotal: 1, Path: dir}, last)
+	ass.NoDirExists(dir)
+}
+
 func TestDeleteDirs(t *testing.T) {
 	t.Parallel()
 	ass := assert.New(t)
@@ -546,6 +708,28 @@ func TestDeleteDirs(t *testing.T) {
 	}
 }
 
+func TestDeleteDirsBestEffort(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	dir := "./testdata/TestDeleteDirsBestEffort/"
+
+	paths := []string{
+		dir + "test-dir-delete1",
+		dir + "test-dir-delete2",
+	}
+
+	_ = CreateDirs(paths...)
+
+	ass.Nil(DeleteDirsBestEffort(paths...))
+
+	for _, path := range paths {
+		ass.NoDirExists(path)
+	}
+
+	_ = DeleteDirs(dir)
+}
+
 func TestDeleteEmptyDirWalk(t *testing.T) {
 	t.Parallel()
 	ass := assert.New(t)
@@ -614,16 +798,61 @@ func TestZip(t *testing.T) {
 
 	dir := "./testdata/TestZip/"
 
-	_ = CreateDirs(dir)
+	_ = CreateFilesWithDirs(dir + "test-dir-walk/test-file.txt")
 
 	// target := "./test-file-zip.zip"
 
 	target := dir + "test-file-zip.zip"
 
 	// 将test-dir-walk 压缩到 ./testdata/TestZip/test-file-zip.zip
-	_ = Zip("./test-dir-walk", target)
+	_ = Zip(dir+"test-dir-walk", target)
+
+	ass.FileExists(target)
+
+	_ = DeleteDirs(dir)
+}
+
+func TestZipContext(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	dir := "./testdata/TestZipContext/"
+
+	_ = CreateDirs(dir + "test-dir/test-dir2")
+	_ = CreateFileWithData(dir+"test-dir/test-file1.txt", "test")
+	_ = CreateFileWithData(dir+"test-dir/test-dir2/test-file2.txt", "test2")
+
+	target := dir + "test-file-zip.zip"
+
+	var last Progress
+	var calls int
+
+	ass.Nil(ZipContext(context.Background(), dir+"test-dir", target, func(p Progress) {
+		last = p
+		calls++
+	}))
 
 	ass.FileExists(target)
+	ass.Equal(9, int(last.BytesDone))
+	ass.Equal(last.BytesTotal, last.BytesDone)
+	ass.Equal(2, last.FilesDone)
+	ass.Equal(last.FilesTotal, last.FilesDone)
+	ass.Greater(calls, 2)
+
+	// 取消后不会留下任何文件
+	ctx, cancel := context.WithCancel(context.Background())
+
+	err := ZipContext(ctx, dir+"test-dir", dir+"test-file-cancel.zip", func(p Progress) {
+		cancel()
+	})
+
+	ass.ErrorIs(err, context.Canceled)
+
+	names, _ := Filenames(dir)
+	ass.Equal([]string{"test-file-zip.zip"}, names)
+
+	// 压缩到源目录中
+	ass.Nil(Zip(dir, dir+"test-file-zip2.zip"))
 
 	_ = DeleteDirs(dir)
 }
@@ -652,6 +881,78 @@ func TestZipFilter(t *testing.T) {
 	_ = DeleteDirs(dir)
 }
 
+func TestZipFilterContext(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	dir := "./testdata/TestZipFilterContext/"
+
+	_ = CreateDirs(dir + "test-dir/test-dir2")
+	_ = CreateFileWithData(dir+"test-dir/test-file1.txt", "test")
+	_ = CreateFileWithData(dir+"test-dir/test-file2.log", "test2")
+	_ = CreateFileWithData(dir+"test-dir/test-dir2/test-file3.txt", "test3")
+
+	target := dir + "test-file-zip.zip"
+
+	var (
+		last  Progress
+		calls = make(map[string]int)
+	)
+
+	ass.Nil(ZipFilterContext(context.Background(), dir+"test-dir", target, func(path string, entry os.DirEntry) bool {
+		calls[path]++
+		return entry.IsDir() || filepath.Ext(path) == ".txt"
+	}, func(p Progress) {
+		last = p
+	}))
+
+	// 每个文件或目录只调用一次 iteratee
+	for path, n := range calls {
+		ass.Equal(1, n, path)
+	}
+	ass.Len(calls, 5)
+
+	ass.Equal(Progress{BytesDone: 9, BytesTotal: 9, FilesDone: 2, FilesTotal: 2, Path: filepath.Join(dir, "test-dir/test-file1.txt")}, last)
+
+	r, err := zip.OpenReader(target)
+	ass.Nil(err)
+
+	var names []string
+	for _, f := range r.File {
+		names = append(names, f.Name)
+	}
+	_ = r.Close()
+
+	ass.ElementsMatch([]string{"./", "test-dir2/", "test-dir2/test-file3.txt", "test-file1.txt"}, names)
+
+	// 取消后不会留下任何文件
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	ass.ErrorIs(ZipFilterContext(ctx, dir+"test-dir", dir+"test-file-cancel.zip", func(path string, entry os.DirEntry) bool {
+		return true
+	}, nil), context.Canceled)
+
+	names, _ = Filenames(dir)
+	ass.Equal([]string{"test-file-zip.zip"}, names)
+
+	// 压缩到源目录中时，有无进度回调 iteratee 都不会收到临时文件
+	for _, progress := range []ProgressFunc{nil, func(p Progress) {}} {
+		var paths []string
+
+		ass.Nil(ZipFilterContext(context.Background(), dir+"test-dir", dir+"test-dir/test-file-zip.zip", func(path string, entry os.DirEntry) bool {
+			paths = append(paths, filepath.Base(path))
+			return true
+		}, progress))
+
+		ass.Equal([]string{"test-dir", "test-dir2", "test-file3.txt", "test-file1.txt", "test-file2.log"}, paths)
+
+		_ = os.Remove(dir + "test-dir/test-file-zip.zip")
+	}
+
+	_ = DeleteDirs(dir)
+}
+
 func TestUnzip(t *testing.T) {
 	t.Parallel()
 	ass := assert.New(t)
@@ -673,6 +974,113 @@ func TestUnzip(t *testing.T) {
 	_ = DeleteDirs(dst)
 }
 
+func TestUnzipContext(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	dir := "./testdata/TestUnzipContext/"
+
+	_ = CreateDirs(dir + "test-dir/test-dir2")
+	_ = CreateFileWithData(dir+"test-dir/test-file1.txt", "test")
+	_ = CreateFileWithData(dir+"test-dir/test-dir2/test-file2.txt", "test2")
+
+	src := dir + "test-file-zip.zip"
+
+	_ = Zip(dir+"test-dir", src)
+
+	var last Progress
+
+	ass.Nil(UnzipContext(context.Background(), src, dir+"test-unzip", func(p Progress) {
+		last = p
+	}))
+
+	ass.Equal(Progress{BytesDone: 9, BytesTotal: 9, FilesDone: 2, FilesTotal: 2, Path: last.Path}, last)
+
+	data, _ := ReadAll(dir + "test-unzip/test-dir2/test-file2.txt")
+	ass.Equal("test2", data)
+
+	// 解压第一个文件后取消，新创建的文件和目录会被删除
+	ctx, cancel := context.WithCancel(context.Background())
+
+	err := UnzipContext(ctx, src, dir+"test-cancel/a", func(p Progress) {
+		if p.FilesDone == 1 {
+			cancel()
+		}
+	})
+
+	ass.ErrorIs(err, context.Canceled)
+	ass.NoDirExists(dir + "test-cancel")
+
+	ass.Error(UnzipContext(context.Background(), dir+"err.zip", dir, nil))
+
+	_ = DeleteDirs(dir)
+}
+
+func TestCompressFile(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	dir := "./testdata/TestCompressFile/"
+
+	path := dir + "test-file-data.txt"
+
+	target := dir + "test-file-data.txt.gz"
+
+	_ = CreateDirs(dir)
+	_ = CreateFileWithData(path, "test\ntest")
+
+	ass.Nil(CompressFile(path, target))
+	ass.FileExists(target)
+
+	data, err := ReadAll(target)
+	ass.Nil(err)
+	ass.Equal("test\ntest", data)
+
+	ass.Error(CompressFile(dir+"err", target))
+
+	_ = DeleteDirs(dir)
+}
+
+func TestDecompressFile(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	dir := "./testdata/TestDecompressFile/"
+
+	path := dir + "test-file-data.txt"
+
+	src := dir + "test-file-data.txt.gz"
+
+	dst := dir + "test-file-data2.txt"
+
+	_ = CreateDirs(dir)
+	_ = CreateFileWithData(path, "test")
+	_ = CompressFile(path, src)
+
+	ass.Nil(DecompressFile(src, dst))
+
+	data, _ := ReadAll(dst)
+	ass.Equal("test", data)
+
+	// 非 gzip 文件
+	ass.Error(DecompressFile(path, dst))
+
+	// gzip 文件损坏时不会覆盖 dst，也不会留下临时文件
+	b, _ := os.ReadFile(src)
+	_ = os.WriteFile(dir+"test-file-bad.gz", b[:len(b)-4], 0644)
+
+	_ = os.WriteFile(dst, []byte("old"), 0644)
+	ass.Error(DecompressFile(dir+"test-file-bad.gz", dst))
+
+	data, _ = ReadAll(dst)
+	ass.Equal("old", data)
+
+	names, _ := Filenames(dir)
+	ass.ElementsMatch([]string{"test-file-data.txt", "test-file-data.txt.gz", "test-file-data2.txt", "test-file-bad.gz"}, names)
+
+	_ = DeleteDirs(dir)
+}
+
 func TestReadAll(t *testing.T) {
 	t.Parallel()
 	ass := assert.New(t)
@@ -690,6 +1098,18 @@ func TestReadAll(t *testing.T) {
 
 	ass.Equal(data, data2)
 
+	// 空文件
+	empty := dir + "test-file-empty.txt"
+	_ = CreateFiles(empty)
+
+	data3, err := ReadAll(empty)
+
+	ass.Nil(err)
+	ass.Empty(data3)
+
+	_, err = ReadAll(dir + "err")
+	ass.Error(err)
+
 	_ = DeleteDirs(dir)
 }
 
@@ -714,6 +1134,14 @@ func TestReadLines(t *testing.T) {
 
 	ass.Empty(data3)
 
+	gzPath := dir + "test-file-data.txt.gz"
+
+	_ = CompressFile(path, gzPath)
+
+	data4, _ := ReadLines(gzPath, -1)
+
+	ass.Equal([]string{"test", "test"}, data4)
+
 	_ = DeleteDirs(dir)
 }
 
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package filejez
+
+import "os"
+
+// 返回文件的硬链接数量，无法获取时视为 1
+func linkCount(info os.FileInfo) uint64 {
+	return 1
+}
@@ -0,0 +1,16 @@
+//go:build unix
+
+package filejez
+
+import (
+	"os"
+	"syscall"
+)
+
+// 返回文件的硬链接数量
+func linkCount(info os.FileInfo) uint64 {
+	if st, ok := info.Sys().(*syscall.Stat_t); ok {
+		return uint64(st.Nlink)
+	}
+	return 1
+}
@@ -0,0 +1,185 @@
+package filejez
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// Progress 长时间文件操作的进度信息
+type Progress struct {
+	BytesDone  int64  // 已处理的字节数
+	BytesTotal int64  // 总字节数，删除操作中为 0
+	FilesDone  int    // 已处理的文件数
+	FilesTotal int    // 总文件数
+	Path       string // 当前处理的路径
+}
+
+// ProgressFunc 进度回调函数，在同一个 goroutine 中同步调用，不应执行耗时操作
+type ProgressFunc func(p Progress)
+
+// 记录进度并在写入时检查 ctx
+type progressTracker struct {
+	ctx context.Context
+	fn  ProgressFunc
+	p   Progress
+}
+
+func newProgressTracker(ctx context.Context, fn ProgressFunc) *progressTracker {
+	return &progressTracker{ctx: ctx, fn: fn}
+}
+
+func (t *progressTracker) report() {
+	if t.fn != nil {
+		t.fn(t.p)
+	}
+}
+
+// 开始处理 path
+func (t *progressTracker) start(path string) error {
+	if err := t.ctx.Err(); err != nil {
+		return err
+	}
+	t.p.Path = path
+	t.report()
+	return nil
+}
+
+// 完成一个文件
+func (t *progressTracker) done() {
+	t.p.FilesDone++
+	t.report()
+}
+
+// 等同于 io.Copy，每次写入前检查 ctx，写入后更新进度
+func (t *progressTracker) copy(dst io.Writer, src io.Reader) (int64, error) {
+	return io.Copy(&progressWriter{w: dst, t: t}, src)
+}
+
+type progressWriter struct {
+	w io.Writer
+	t *progressTracker
+}
+
+func (pw *progressWriter) Write(b []byte) (int, error) {
+	if err := pw.t.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	n, err := pw.w.Write(b)
+
+	pw.t.p.BytesDone += int64(n)
+	pw.t.report()
+
+	return n, err
+}
+
+// 先写入 dst 同目录下的临时文件，成功后再重命名为 dst，失败时删除临时文件，不会留下写了一半的文件
+//
+// 权限与 os.OpenFile(dst, os.O_CREATE|os.O_TRUNC, perm) 一致：dst 不存在时为 perm（受 umask 影响），已存在时保留原有的权限，
+// dst 为符号链接时替换其指向的文件，符号链接本身保持不变。
+//
+// 只有 dst 不存在或是只有一个硬链接的普通文件时才会重命名，原有的属主和扩展属性不会保留；
+// 其他情况（如设备文件、有多个硬链接的文件）直接写入 dst，与 os.OpenFile 相同，失败时可能留下写了一半的内容。
+func writeFileAtomic(dst string, perm os.FileMode, write func(f *os.File) error) (err error) {
+	if target, err := filepath.EvalSymlinks(dst); err == nil {
+		dst = target
+	}
+
+	info, statErr := os.Stat(dst)
+	if statErr == nil && (!info.Mode().IsRegular() || linkCount(info) > 1) {
+		return writeFileInPlace(dst, perm, write)
+	}
+
+	tmp, err := createTempFile(dst, perm)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	if err = write(tmp); err != nil {
+		return err
+	}
+
+	if statErr == nil {
+		if err = tmp.Chmod(info.Mode().Perm()); err != nil {
+			return err
+		}
+	}
+
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), dst)
+}
+
+// 直接打开 dst 并写入，与 os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm) 相同
+func writeFileInPlace(dst string, perm os.FileMode, write func(f *os.File) error) error {
+	f, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+
+	if err = write(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// 在 dst 同目录下创建临时文件，与 os.CreateTemp 不同，创建时使用 perm 作为权限，会受 umask 影响
+func createTempFile(dst string, perm os.FileMode) (*os.File, error) {
+	prefix := filepath.Join(filepath.Dir(dst), "."+filepath.Base(dst)+".tmp-")
+
+	for i := 0; ; i++ {
+		f, err := os.OpenFile(prefix+strconv.FormatUint(uint64(rand.Uint32()), 36), os.O_RDWR|os.O_CREATE|os.O_EXCL, perm)
+		if errors.Is(err, os.ErrExist) && i < 100 {
+			continue
+		}
+		return f, err
+	}
+}
+
+// 等同于 os.MkdirAll，并将新创建的目录按创建顺序追加到 created 中
+func mkdirAllTrack(dirPath string, created *[]string) error {
+	var missing []string
+
+	for path := dirPath; ; path = filepath.Dir(path) {
+		_, err := os.Stat(path)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+
+		missing = append(missing, path)
+
+		if filepath.Dir(path) == path {
+			break
+		}
+	}
+
+	for i := len(missing) - 1; i >= 0; i-- {
+		err := os.Mkdir(missing[i], os.ModePerm)
+		if err == nil {
+			*created = append(*created, missing[i])
+		} else if !errors.Is(err, os.ErrExist) {
+			return err
+		}
+	}
+
+	return nil
+}
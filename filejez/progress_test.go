@@ -0,0 +1,110 @@
+package filejez
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_writeFileAtomic(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	dir := "./testdata/Test_writeFileAtomic/"
+
+	path := dir + "test-file.txt"
+
+	_ = CreateDirs(dir)
+
+	ass.Nil(writeFileAtomic(path, 0600, func(f *os.File) error {
+		_, err := f.WriteString("test")
+		return err
+	}))
+
+	data, _ := ReadAll(path)
+	ass.Equal("test", data)
+
+	info, _ := os.Stat(path)
+	ass.Equal(os.FileMode(0600), info.Mode().Perm())
+
+	// 失败时不会覆盖原文件，也不会留下临时文件
+	err := errors.New("err")
+
+	ass.ErrorIs(writeFileAtomic(path, 0600, func(f *os.File) error {
+		_, _ = f.WriteString("test2")
+		return err
+	}), err)
+
+	data, _ = ReadAll(path)
+	ass.Equal("test", data)
+
+	names, _ := Filenames(dir)
+	ass.Equal([]string{"test-file.txt"}, names)
+
+	// 已存在时保留原有的权限
+	_ = os.Chmod(path, 0640)
+
+	ass.Nil(writeFileAtomic(path, 0600, func(f *os.File) error {
+		_, err := f.WriteString("test3")
+		return err
+	}))
+
+	info, _ = os.Stat(path)
+	ass.Equal(os.FileMode(0640), info.Mode().Perm())
+
+	// 符号链接保持不变，替换其指向的文件
+	link := dir + "test-link.txt"
+	ass.Nil(os.Symlink("test-file.txt", link))
+
+	ass.Nil(writeFileAtomic(link, 0600, func(f *os.File) error {
+		_, err := f.WriteString("test4")
+		return err
+	}))
+
+	info, _ = os.Lstat(link)
+	ass.Equal(os.ModeSymlink, info.Mode().Type())
+
+	data, _ = ReadAll(path)
+	ass.Equal("test4", data)
+
+	// 有多个硬链接时直接写入，其他链接也能读取到新的内容
+	hardLink := dir + "test-hard-link.txt"
+	ass.Nil(os.Link(path, hardLink))
+
+	ass.Nil(writeFileAtomic(path, 0600, func(f *os.File) error {
+		_, err := f.WriteString("test5")
+		return err
+	}))
+
+	data, _ = ReadAll(hardLink)
+	ass.Equal("test5", data)
+
+	info, _ = os.Stat(path)
+	ass.Equal(os.FileMode(0640), info.Mode().Perm())
+
+	_ = DeleteDirs(dir)
+}
+
+func Test_mkdirAllTrack(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	dir := "./testdata/Test_mkdirAllTrack/"
+
+	_ = CreateDirs(dir)
+
+	var created []string
+
+	ass.Nil(mkdirAllTrack(dir+"a/b", &created))
+	ass.Equal([]string{filepath.Join(dir, "a"), dir + "a/b"}, created)
+
+	created = nil
+
+	ass.Nil(mkdirAllTrack(dir+"a/c", &created))
+	ass.Equal([]string{dir + "a/c"}, created)
+
+	_ = DeleteDirs(dir)
+}
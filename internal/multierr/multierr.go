@@ -0,0 +1,47 @@
+// Package multierr 聚合错误的公共实现，供各个包的聚合错误类型复用
+package multierr
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Format 返回 "<pkg>: <n> <noun> occurred:"，之后每行一个错误，如 "filejez: 2 error(s) occurred:\n\t..."
+func Format[E error](pkg, noun string, errs []E) string {
+	var b strings.Builder
+
+	b.WriteString(pkg)
+	b.WriteString(": ")
+	b.WriteString(strconv.Itoa(len(errs)))
+	b.WriteString(" ")
+	b.WriteString(noun)
+	b.WriteString(" occurred:")
+
+	for _, err := range errs {
+		b.WriteString("\n\t")
+		b.WriteString(err.Error())
+	}
+
+	return b.String()
+}
+
+// Is 判断 errs 中是否有任意一个错误匹配 target
+func Is[E error](errs []E, target error) bool {
+	for _, err := range errs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As 查找 errs 中第一个可以赋值给 target 的错误
+func As[E error](errs []E, target any) bool {
+	for _, err := range errs {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package multierr
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	errs := []*fs.PathError{
+		{Op: "open", Path: "a", Err: fs.ErrNotExist},
+		{Op: "open", Path: "b", Err: fs.ErrPermission},
+	}
+
+	ass.Equal("pkg: 2 error(s) occurred:\n\topen a: file does not exist\n\topen b: permission denied", Format("pkg", "error(s)", errs))
+	ass.Equal("pkg: 0 error(s) occurred:", Format[error]("pkg", "error(s)", nil))
+}
+
+func TestIsAs(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	errs := []*fs.PathError{
+		{Op: "open", Path: "a", Err: fs.ErrNotExist},
+		{Op: "open", Path: "b", Err: fs.ErrPermission},
+	}
+
+	ass.True(Is(errs, fs.ErrPermission))
+	ass.False(Is(errs, fs.ErrExist))
+	ass.False(Is[error](nil, fs.ErrExist))
+
+	var pe *fs.PathError
+	ass.True(As(errs, &pe))
+	ass.Equal("a", pe.Path)
+
+	var le *fs.PathError
+	ass.False(As([]error{errors.New("x")}, &le))
+}
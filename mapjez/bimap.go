@@ -0,0 +1,158 @@
+package mapjez
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrValueBound value已经绑定到其他key。
+var ErrValueBound = errors.New("mapjez: value already bound to another key")
+
+// ConflictPolicy value已经绑定到其他key时的处理方式。
+type ConflictPolicy int
+
+const (
+	// ConflictError 返回 ErrValueBound，不做修改
+	ConflictError ConflictPolicy = iota
+	// ConflictReplace 删除原有的绑定后再绑定
+	ConflictReplace
+	// ConflictPanic 直接 panic
+	ConflictPanic
+)
+
+// BiMap 双向map，key和value一一对应，通过key查找value和通过value查找key的时间复杂度均为 O(1)，非并发安全。
+//
+// 需要使用 NewBiMap 创建。
+type BiMap[K, V comparable] struct {
+	forward  map[K]V
+	backward map[V]K
+	policy   ConflictPolicy
+}
+
+// NewBiMap 创建一个双向map，policy 为value已经绑定到其他key时的处理方式。
+func NewBiMap[K, V comparable](policy ConflictPolicy) *BiMap[K, V] {
+	return &BiMap[K, V]{
+		forward:  make(map[K]V),
+		backward: make(map[V]K),
+		policy:   policy,
+	}
+}
+
+// NewBiMapFromMap 从普通map创建一个双向map，如果存在重复的value，按 policy 处理，ConflictReplace 时保留的key不确定。
+func NewBiMapFromMap[K, V comparable](m map[K]V, policy ConflictPolicy) (*BiMap[K, V], error) {
+	b := NewBiMap[K, V](policy)
+
+	for k, v := range m {
+		if err := b.Put(k, v); err != nil {
+			return nil, err
+		}
+	}
+
+	return b, nil
+}
+
+// Put 绑定 key 和 value，key 已存在时解除与原value的绑定，value 已经绑定到其他key时按 policy 处理。
+func (b *BiMap[K, V]) Put(key K, value V) error {
+	if k, ok := b.backward[value]; ok && k != key {
+		switch b.policy {
+		case ConflictReplace:
+			delete(b.forward, k)
+		case ConflictPanic:
+			panic(fmt.Errorf("%w: %v is bound to %v", ErrValueBound, value, k))
+		default:
+			return fmt.Errorf("%w: %v is bound to %v", ErrValueBound, value, k)
+		}
+	}
+
+	if v, ok := b.forward[key]; ok {
+		delete(b.backward, v)
+	}
+
+	b.forward[key] = value
+	b.backward[value] = key
+
+	return nil
+}
+
+// Get 返回 key 对应的value，如果不存在，ok 为 false。
+func (b *BiMap[K, V]) Get(key K) (value V, ok bool) {
+	value, ok = b.forward[key]
+	return
+}
+
+// GetKey 返回 value 对应的key，如果不存在，ok 为 false。
+func (b *BiMap[K, V]) GetKey(value V) (key K, ok bool) {
+	key, ok = b.backward[value]
+	return
+}
+
+// Has 判断 key 是否存在。
+func (b *BiMap[K, V]) Has(key K) bool {
+	_, ok := b.forward[key]
+	return ok
+}
+
+// HasValue 判断 value 是否存在。
+func (b *BiMap[K, V]) HasValue(value V) bool {
+	_, ok := b.backward[value]
+	return ok
+}
+
+// Deletes 通过key删除多个元素。
+func (b *BiMap[K, V]) Deletes(keys ...K) {
+	for _, k := range keys {
+		if v, ok := b.forward[k]; ok {
+			delete(b.forward, k)
+			delete(b.backward, v)
+		}
+	}
+}
+
+// DeleteByValues 通过value删除多个元素。
+func (b *BiMap[K, V]) DeleteByValues(values ...V) {
+	for _, v := range values {
+		if k, ok := b.backward[v]; ok {
+			delete(b.forward, k)
+			delete(b.backward, v)
+		}
+	}
+}
+
+// Len 返回元素数量。
+func (b *BiMap[K, V]) Len() int {
+	return len(b.forward)
+}
+
+// Keys 返回所有的key，顺序不确定。
+func (b *BiMap[K, V]) Keys() []K {
+	return Keys(b.forward)
+}
+
+// Values 返回所有的value，顺序不确定。
+func (b *BiMap[K, V]) Values() []V {
+	return Keys(b.backward)
+}
+
+// ForEach 遍历所有元素，对每个元素调用 iteratee 函数，iteratee 中不能修改 BiMap。
+func (b *BiMap[K, V]) ForEach(iteratee func(key K, value V)) {
+	ForEach(b.forward, iteratee)
+}
+
+// Inverse 返回key和value互换的视图，与原 BiMap 共享数据，对任意一方的修改都会反映到另一方。
+func (b *BiMap[K, V]) Inverse() *BiMap[V, K] {
+	return &BiMap[V, K]{
+		forward:  b.backward,
+		backward: b.forward,
+		policy:   b.policy,
+	}
+}
+
+// ToMap 返回 key 到 value 的普通map。
+func (b *BiMap[K, V]) ToMap() map[K]V {
+	return Merge(b.forward)
+}
+
+// ToInverseMap 返回 value 到 key 的普通map。
+func (b *BiMap[K, V]) ToInverseMap() map[V]K {
+	return Merge(b.backward)
+}
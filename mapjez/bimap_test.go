@@ -0,0 +1,129 @@
+package mapjez
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBiMap_Put(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	b := NewBiMap[string, int](ConflictError)
+
+	ass.Nil(b.Put("a", 1))
+	ass.Nil(b.Put("b", 2))
+	ass.Nil(b.Put("a", 1))
+
+	// value 已绑定到其他key
+	err := b.Put("c", 1)
+	ass.True(errors.Is(err, ErrValueBound))
+	ass.False(b.Has("c"))
+
+	// 更新key的value，解除与原value的绑定
+	ass.Nil(b.Put("a", 3))
+	ass.False(b.HasValue(1))
+
+	v, ok := b.Get("a")
+	ass.True(ok)
+	ass.Equal(3, v)
+
+	k, ok := b.GetKey(2)
+	ass.True(ok)
+	ass.Equal("b", k)
+
+	_, ok = b.Get("x")
+	ass.False(ok)
+
+	_, ok = b.GetKey(1)
+	ass.False(ok)
+
+	ass.Equal(map[string]int{"a": 3, "b": 2}, b.ToMap())
+	ass.Equal(map[int]string{3: "a", 2: "b"}, b.ToInverseMap())
+	ass.Equal(2, b.Len())
+	ass.ElementsMatch([]string{"a", "b"}, b.Keys())
+	ass.ElementsMatch([]int{2, 3}, b.Values())
+}
+
+func TestBiMap_Policy(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	b := NewBiMap[string, int](ConflictReplace)
+	b.Put("a", 1)
+	b.Put("b", 2)
+
+	ass.Nil(b.Put("b", 1))
+	ass.Equal(map[string]int{"b": 1}, b.ToMap())
+	ass.Equal(map[int]string{1: "b"}, b.ToInverseMap())
+
+	p := NewBiMap[string, int](ConflictPanic)
+	p.Put("a", 1)
+
+	ass.Panics(func() {
+		p.Put("b", 1)
+	})
+	ass.Equal(map[string]int{"a": 1}, p.ToMap())
+}
+
+func TestNewBiMapFromMap(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	b, err := NewBiMapFromMap(map[string]int{"a": 1, "b": 2}, ConflictError)
+	ass.Nil(err)
+	ass.Equal(map[int]string{1: "a", 2: "b"}, b.ToInverseMap())
+
+	_, err = NewBiMapFromMap(map[string]int{"a": 1, "b": 1}, ConflictError)
+	ass.True(errors.Is(err, ErrValueBound))
+
+	b, err = NewBiMapFromMap(map[string]int{"a": 1, "b": 1}, ConflictReplace)
+	ass.Nil(err)
+	ass.Equal(1, b.Len())
+}
+
+func TestBiMap_Deletes(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	b, _ := NewBiMapFromMap(map[string]int{"a": 1, "b": 2, "c": 3, "d": 4}, ConflictError)
+
+	b.Deletes("a", "x")
+	b.DeleteByValues(2, 5)
+
+	ass.Equal(map[string]int{"c": 3, "d": 4}, b.ToMap())
+	ass.Equal(map[int]string{3: "c", 4: "d"}, b.ToInverseMap())
+}
+
+func TestBiMap_Inverse(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	b := NewBiMap[string, int](ConflictError)
+	b.Put("a", 1)
+
+	inv := b.Inverse()
+
+	k, ok := inv.Get(1)
+	ass.True(ok)
+	ass.Equal("a", k)
+
+	// 修改视图会反映到原 BiMap
+	ass.Nil(inv.Put(2, "b"))
+	ass.True(errors.Is(inv.Put(3, "a"), ErrValueBound))
+
+	v, ok := b.Get("b")
+	ass.True(ok)
+	ass.Equal(2, v)
+
+	b.Deletes("a")
+	ass.False(inv.Has(1))
+
+	result := make(map[int]string)
+	inv.ForEach(func(key int, value string) {
+		result[key] = value
+	})
+	ass.Equal(map[int]string{2: "b"}, result)
+}
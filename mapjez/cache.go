@@ -0,0 +1,408 @@
+package mapjez
+
+import (
+	"container/heap"
+	"sort"
+	"sync"
+	"time"
+)
+
+// EvictionPolicy 缓存的淘汰策略。
+type EvictionPolicy int
+
+const (
+	// LRU 淘汰最久未使用的元素
+	LRU EvictionPolicy = iota
+	// LFU 淘汰使用次数最少的元素，次数相同时淘汰最久未使用的元素
+	LFU
+)
+
+// EvictReason 元素被移除的原因。
+type EvictReason int
+
+const (
+	// EvictCapacity 超出容量被淘汰
+	EvictCapacity EvictReason = iota
+	// EvictExpired 已过期
+	EvictExpired
+)
+
+// CacheOptions 缓存的配置。
+type CacheOptions[K comparable, V any] struct {
+	Capacity int                                      // 最大元素数量，<= 0 表示不限制
+	Policy   EvictionPolicy                           // 淘汰策略，默认为 LRU
+	TTL      time.Duration                            // 默认的过期时间，<= 0 表示不过期
+	Now      func() time.Time                         // 获取当前时间，默认为 time.Now，可以在测试中替换
+	OnEvict  func(key K, value V, reason EvictReason) // 元素被淘汰或过期时调用，调用时不持有锁
+	Safe     bool                                     // 是否并发安全
+}
+
+// CacheStats 缓存的统计信息。
+type CacheStats struct {
+	Hits        int64 // 命中次数
+	Misses      int64 // 未命中次数，包括已过期的元素
+	Evictions   int64 // 超出容量被淘汰的次数
+	Expirations int64 // 过期被移除的次数
+}
+
+type cacheEntry[K comparable, V any] struct {
+	key      K
+	value    V
+	expireAt time.Time // 零值表示不过期
+	freq     int64     // 使用次数
+	tick     int64     // 最后一次使用的序号
+	index    int       // 在 cacheHeap 中的位置
+	expIndex int       // 在 expiryHeap 中的位置，不过期时为 -1
+}
+
+type evicted[K comparable, V any] struct {
+	entry  *cacheEntry[K, V]
+	reason EvictReason
+}
+
+// Cache 有容量限制的缓存，支持 LRU、LFU 淘汰策略和过期时间，过期的元素在访问时被移除，也可以调用 DeleteExpired 主动移除。
+type Cache[K comparable, V any] struct {
+	opts    CacheOptions[K, V]
+	m       map[K]*cacheEntry[K, V]
+	h       cacheHeap[K, V]
+	exp     expiryHeap[K, V] // 只包含有过期时间的元素
+	tick    int64
+	stats   CacheStats
+	mu      *sync.Mutex // Safe 为 false 时为 nil
+	evicted []evicted[K, V]
+}
+
+// NewCache 创建一个缓存。
+func NewCache[K comparable, V any](opts CacheOptions[K, V]) *Cache[K, V] {
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+
+	c := &Cache[K, V]{
+		opts: opts,
+		m:    make(map[K]*cacheEntry[K, V]),
+	}
+
+	c.h.policy = opts.Policy
+
+	if opts.Safe {
+		c.mu = new(sync.Mutex)
+	}
+
+	return c
+}
+
+func (c *Cache[K, V]) lock() {
+	if c.mu != nil {
+		c.mu.Lock()
+	}
+}
+
+// 解锁后调用 OnEvict
+func (c *Cache[K, V]) unlock() {
+	list := c.evicted
+	c.evicted = nil
+
+	if c.mu != nil {
+		c.mu.Unlock()
+	}
+
+	if c.opts.OnEvict == nil {
+		return
+	}
+
+	for _, e := range list {
+		c.opts.OnEvict(e.entry.key, e.entry.value, e.reason)
+	}
+}
+
+func (c *Cache[K, V]) expired(e *cacheEntry[K, V], now time.Time) bool {
+	return !e.expireAt.IsZero() && !now.Before(e.expireAt)
+}
+
+// 从 map 和两个堆中删除元素
+func (c *Cache[K, V]) unlink(e *cacheEntry[K, V]) {
+	heap.Remove(&c.h, e.index)
+	if e.expIndex >= 0 {
+		heap.Remove(&c.exp, e.expIndex)
+	}
+	delete(c.m, e.key)
+}
+
+// 设置过期时间，并更新 expiryHeap
+func (c *Cache[K, V]) setExpireAt(e *cacheEntry[K, V], expireAt time.Time) {
+	e.expireAt = expireAt
+
+	if expireAt.IsZero() {
+		if e.expIndex >= 0 {
+			heap.Remove(&c.exp, e.expIndex)
+		}
+		return
+	}
+
+	if e.expIndex >= 0 {
+		heap.Fix(&c.exp, e.expIndex)
+	} else {
+		heap.Push(&c.exp, e)
+	}
+}
+
+func (c *Cache[K, V]) remove(e *cacheEntry[K, V], reason EvictReason) {
+	c.unlink(e)
+
+	switch reason {
+	case EvictCapacity:
+		c.stats.Evictions++
+	case EvictExpired:
+		c.stats.Expirations++
+	}
+
+	if c.opts.OnEvict != nil {
+		c.evicted = append(c.evicted, evicted[K, V]{entry: e, reason: reason})
+	}
+}
+
+// 更新使用次数和最后一次使用的序号
+func (c *Cache[K, V]) touch(e *cacheEntry[K, V]) {
+	c.tick++
+	e.tick = c.tick
+	e.freq++
+	heap.Fix(&c.h, e.index)
+}
+
+// 返回未过期的元素
+func (c *Cache[K, V]) lookup(key K) (*cacheEntry[K, V], bool) {
+	e, ok := c.m[key]
+	if !ok {
+		return nil, false
+	}
+
+	if c.expired(e, c.opts.Now()) {
+		c.remove(e, EvictExpired)
+		return nil, false
+	}
+
+	return e, true
+}
+
+// Get 返回 key 对应的value，如果不存在或已过期，ok 为 false，会更新命中统计和淘汰顺序。
+func (c *Cache[K, V]) Get(key K) (value V, ok bool) {
+	c.lock()
+	defer c.unlock()
+
+	e, ok := c.lookup(key)
+	if !ok {
+		c.stats.Misses++
+		return
+	}
+
+	c.stats.Hits++
+	c.touch(e)
+
+	return e.value, true
+}
+
+// Peek 返回 key 对应的value，如果不存在或已过期，ok 为 false，不会更新命中统计和淘汰顺序。
+func (c *Cache[K, V]) Peek(key K) (value V, ok bool) {
+	c.lock()
+	defer c.unlock()
+
+	e, ok := c.lookup(key)
+	if !ok {
+		return
+	}
+
+	return e.value, true
+}
+
+// Set 设置 key 对应的value，使用默认的过期时间。
+func (c *Cache[K, V]) Set(key K, value V) {
+	c.SetWithTTL(key, value, c.opts.TTL)
+}
+
+// SetWithTTL 设置 key 对应的value，并指定过期时间，ttl <= 0 表示不过期，
+// 超出容量时先移除所有已过期的元素，仍然超出容量时再按淘汰策略淘汰元素。
+func (c *Cache[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	c.lock()
+	defer c.unlock()
+
+	var expireAt time.Time
+	if ttl > 0 {
+		expireAt = c.opts.Now().Add(ttl)
+	}
+
+	if e, ok := c.m[key]; ok {
+		e.value = value
+		c.setExpireAt(e, expireAt)
+		c.touch(e)
+		return
+	}
+
+	// 先淘汰再插入，避免 LFU 策略下新元素被立即淘汰
+	if c.opts.Capacity > 0 && len(c.m) >= c.opts.Capacity {
+		// 优先移除已过期的元素，避免淘汰未过期的元素，只会读取 expiryHeap 的堆顶，不会遍历所有元素
+		if c.deleteExpired() == 0 {
+			c.remove(c.h.items[0], EvictCapacity)
+		}
+	}
+
+	e := &cacheEntry[K, V]{key: key, value: value, expIndex: -1}
+	c.m[key] = e
+	heap.Push(&c.h, e)
+	c.setExpireAt(e, expireAt)
+	c.touch(e)
+}
+
+// Deletes 通过key删除多个元素，不会调用 OnEvict。
+func (c *Cache[K, V]) Deletes(keys ...K) {
+	c.lock()
+	defer c.unlock()
+
+	for _, k := range keys {
+		if e, ok := c.m[k]; ok {
+			c.unlink(e)
+		}
+	}
+}
+
+// 按过期时间从早到晚移除已过期的元素，时间复杂度为 O(k log n)，k 为过期元素的数量
+func (c *Cache[K, V]) deleteExpired() int {
+	now := c.opts.Now()
+
+	n := 0
+	for len(c.exp.items) > 0 && c.expired(c.exp.items[0], now) {
+		c.remove(c.exp.items[0], EvictExpired)
+		n++
+	}
+
+	return n
+}
+
+// DeleteExpired 移除所有已过期的元素，返回移除的数量。
+func (c *Cache[K, V]) DeleteExpired() int {
+	c.lock()
+	defer c.unlock()
+	return c.deleteExpired()
+}
+
+// Clear 清空缓存，不会调用 OnEvict，也不会重置统计信息。
+func (c *Cache[K, V]) Clear() {
+	c.lock()
+	defer c.unlock()
+
+	c.m = make(map[K]*cacheEntry[K, V])
+	c.h.items = nil
+	c.exp.items = nil
+}
+
+// Len 返回缓存中的元素数量，可能包含已过期但尚未移除的元素。
+func (c *Cache[K, V]) Len() int {
+	c.lock()
+	defer c.unlock()
+	return len(c.m)
+}
+
+// Keys 返回所有未过期的key，按淘汰顺序排列，最先被淘汰的在前面。
+func (c *Cache[K, V]) Keys() []K {
+	c.lock()
+	defer c.unlock()
+
+	now := c.opts.Now()
+
+	list := make([]*cacheEntry[K, V], 0, len(c.h.items))
+	for _, e := range c.h.items {
+		if !c.expired(e, now) {
+			list = append(list, e)
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return c.h.less(list[i], list[j])
+	})
+
+	keys := make([]K, 0, len(list))
+	for _, e := range list {
+		keys = append(keys, e.key)
+	}
+
+	return keys
+}
+
+// Stats 返回统计信息。
+func (c *Cache[K, V]) Stats() CacheStats {
+	c.lock()
+	defer c.unlock()
+	return c.stats
+}
+
+// 按淘汰顺序排列的小顶堆，堆顶为下一个被淘汰的元素
+type cacheHeap[K comparable, V any] struct {
+	policy EvictionPolicy
+	items  []*cacheEntry[K, V]
+}
+
+func (h *cacheHeap[K, V]) Len() int { return len(h.items) }
+
+// a 是否比 b 先被淘汰
+func (h *cacheHeap[K, V]) less(a, b *cacheEntry[K, V]) bool {
+	if h.policy == LFU && a.freq != b.freq {
+		return a.freq < b.freq
+	}
+	return a.tick < b.tick
+}
+
+func (h *cacheHeap[K, V]) Less(i, j int) bool {
+	return h.less(h.items[i], h.items[j])
+}
+
+func (h *cacheHeap[K, V]) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.items[i].index = i
+	h.items[j].index = j
+}
+
+func (h *cacheHeap[K, V]) Push(x any) {
+	e := x.(*cacheEntry[K, V])
+	e.index = len(h.items)
+	h.items = append(h.items, e)
+}
+
+func (h *cacheHeap[K, V]) Pop() any {
+	n := len(h.items) - 1
+	e := h.items[n]
+	h.items[n] = nil
+	h.items = h.items[:n]
+	return e
+}
+
+// 按过期时间排列的小顶堆，堆顶为最先过期的元素
+type expiryHeap[K comparable, V any] struct {
+	items []*cacheEntry[K, V]
+}
+
+func (h *expiryHeap[K, V]) Len() int { return len(h.items) }
+
+func (h *expiryHeap[K, V]) Less(i, j int) bool {
+	return h.items[i].expireAt.Before(h.items[j].expireAt)
+}
+
+func (h *expiryHeap[K, V]) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.items[i].expIndex = i
+	h.items[j].expIndex = j
+}
+
+func (h *expiryHeap[K, V]) Push(x any) {
+	e := x.(*cacheEntry[K, V])
+	e.expIndex = len(h.items)
+	h.items = append(h.items, e)
+}
+
+func (h *expiryHeap[K, V]) Pop() any {
+	n := len(h.items) - 1
+	e := h.items[n]
+	h.items[n] = nil
+	h.items = h.items[:n]
+	e.expIndex = -1
+	return e
+}
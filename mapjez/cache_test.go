@@ -0,0 +1,285 @@
+package mapjez
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// 可以手动调整的时钟，并发安全，TTLMap 的后台清理会在其他 goroutine 中读取
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *testClock) Add(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+}
+
+func TestCache_LRU(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	var evicted []string
+
+	c := NewCache(CacheOptions[string, int]{
+		Capacity: 3,
+		OnEvict: func(key string, value int, reason EvictReason) {
+			ass.Equal(EvictCapacity, reason)
+			evicted = append(evicted, key)
+		},
+	})
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Set("c", 3)
+
+	// a 变为最近使用
+	v, ok := c.Get("a")
+	ass.True(ok)
+	ass.Equal(1, v)
+
+	c.Set("d", 4)
+
+	ass.Equal([]string{"b"}, evicted)
+	ass.Equal([]string{"c", "a", "d"}, c.Keys())
+
+	// Peek 不改变顺序
+	_, ok = c.Peek("c")
+	ass.True(ok)
+
+	c.Set("e", 5)
+
+	ass.Equal([]string{"b", "c"}, evicted)
+	ass.Equal(3, c.Len())
+
+	// 更新已存在的key不会淘汰
+	c.Set("a", 10)
+
+	ass.Equal([]string{"b", "c"}, evicted)
+	ass.Equal([]string{"d", "e", "a"}, c.Keys())
+
+	_, ok = c.Get("b")
+	ass.False(ok)
+
+	ass.Equal(CacheStats{Hits: 1, Misses: 1, Evictions: 2}, c.Stats())
+}
+
+func TestCache_LFU(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	c := NewCache(CacheOptions[string, int]{
+		Capacity: 3,
+		Policy:   LFU,
+	})
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Set("c", 3)
+
+	c.Get("a")
+	c.Get("a")
+	c.Get("b")
+	c.Get("c")
+
+	// b 和 c 使用次数相同，b 更久未使用
+	c.Set("d", 4)
+
+	ass.Equal([]string{"d", "c", "a"}, c.Keys())
+
+	// 新元素不会被立即淘汰
+	c.Set("e", 5)
+
+	_, ok := c.Peek("e")
+	ass.True(ok)
+
+	_, ok = c.Peek("d")
+	ass.False(ok)
+}
+
+func TestCache_TTL(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	clock := &testClock{now: time.Unix(0, 0)}
+
+	var expired []string
+
+	c := NewCache(CacheOptions[string, int]{
+		TTL: time.Minute,
+		Now: clock.Now,
+		OnEvict: func(key string, value int, reason EvictReason) {
+			ass.Equal(EvictExpired, reason)
+			expired = append(expired, key)
+		},
+	})
+
+	c.Set("a", 1)
+	c.SetWithTTL("b", 2, time.Hour)
+	c.SetWithTTL("c", 3, 0)
+
+	clock.Add(time.Minute)
+
+	_, ok := c.Get("a")
+	ass.False(ok)
+	ass.Equal([]string{"a"}, expired)
+
+	_, ok = c.Get("b")
+	ass.True(ok)
+
+	clock.Add(time.Hour)
+
+	ass.Equal([]string{"c"}, c.Keys())
+	ass.Equal(2, c.Len())
+
+	ass.Equal(1, c.DeleteExpired())
+	ass.Equal([]string{"a", "b"}, expired)
+	ass.Equal(1, c.Len())
+
+	ass.Equal(CacheStats{Hits: 1, Misses: 1, Expirations: 2}, c.Stats())
+}
+
+func TestCache_EvictExpiredFirst(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	clock := &testClock{now: time.Unix(0, 0)}
+
+	evicted := make(map[string]EvictReason)
+
+	c := NewCache(CacheOptions[string, int]{
+		Capacity: 2,
+		Now:      clock.Now,
+		OnEvict: func(key string, value int, reason EvictReason) {
+			evicted[key] = reason
+		},
+	})
+
+	// a 最久未使用但未过期，b 已过期
+	c.Set("a", 1)
+	c.SetWithTTL("b", 2, time.Minute)
+	clock.Add(time.Minute)
+
+	c.Set("c", 3)
+
+	ass.ElementsMatch([]string{"a", "c"}, c.Keys())
+	ass.Equal(map[string]EvictReason{"b": EvictExpired}, evicted)
+
+	// 没有过期的元素时按淘汰策略淘汰
+	c.Set("d", 4)
+	ass.ElementsMatch([]string{"c", "d"}, c.Keys())
+	ass.Equal(EvictCapacity, evicted["a"])
+
+	// 更新过期时间
+	c.SetWithTTL("c", 3, time.Minute)
+	c.SetWithTTL("d", 4, time.Minute)
+	c.SetWithTTL("c", 3, 0)
+	c.SetWithTTL("d", 4, time.Hour)
+	clock.Add(time.Minute)
+
+	ass.Equal(0, c.DeleteExpired())
+
+	c.Deletes("c")
+	clock.Add(time.Hour)
+
+	ass.Equal(1, c.DeleteExpired())
+	ass.Equal(0, c.Len())
+}
+
+func TestCache_Deletes(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	c := NewCache(CacheOptions[string, int]{
+		OnEvict: func(key string, value int, reason EvictReason) {
+			ass.Fail("OnEvict should not be called")
+		},
+	})
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Set("c", 3)
+
+	c.Deletes("b", "d")
+
+	ass.Equal([]string{"a", "c"}, c.Keys())
+
+	c.Clear()
+
+	ass.Equal(0, c.Len())
+	ass.Empty(c.Keys())
+}
+
+func TestCache_Safe(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	c := NewCache(CacheOptions[int, int]{
+		Capacity: 100,
+		Safe:     true,
+	})
+
+	// OnEvict 中可以再次访问缓存
+	c.opts.OnEvict = func(key int, value int, reason EvictReason) {
+		c.Peek(key)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 1000; i++ {
+		wg.Add(1)
+		go func(v int) {
+			defer wg.Done()
+			c.Set(v, v)
+			c.Get(v - 1)
+		}(i)
+	}
+	wg.Wait()
+
+	ass.Equal(100, c.Len())
+	ass.Equal(int64(900), c.Stats().Evictions)
+}
+
+// 容量已满时 Set 的耗时不应随容量线性增长，容量增大 64 倍时每次操作的耗时超过 10 倍则失败
+func BenchmarkCache_SetFull(b *testing.B) {
+	capacities := []int{1 << 10, 1 << 16}
+
+	for _, ttl := range []time.Duration{0, time.Hour} {
+		perOp := make(map[int]time.Duration)
+
+		for _, capacity := range capacities {
+			b.Run(fmt.Sprintf("ttl=%v/capacity=%d", ttl, capacity), func(b *testing.B) {
+				c := NewCache(CacheOptions[int, int]{Capacity: capacity, TTL: ttl})
+				for i := 0; i < capacity; i++ {
+					c.Set(i, i)
+				}
+
+				b.ResetTimer()
+				start := time.Now()
+
+				for i := 0; i < b.N; i++ {
+					c.Set(capacity+i, i)
+				}
+
+				perOp[capacity] = time.Since(start) / time.Duration(b.N)
+			})
+		}
+
+		small, large := perOp[capacities[0]], perOp[capacities[len(capacities)-1]]
+		if small > 0 && large > 10*small {
+			b.Fatalf("ttl=%v: Set on a full cache grows with capacity: %v/op at %d, %v/op at %d",
+				ttl, small, capacities[0], large, capacities[len(capacities)-1])
+		}
+	}
+}
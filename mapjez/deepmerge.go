@@ -0,0 +1,74 @@
+package mapjez
+
+// DeepMergeOptions DeepMergeWithOptions 的配置。
+type DeepMergeOptions struct {
+	AppendSlices bool // 两边都是 []any 时追加到末尾，默认为替换
+	NilDeletes   bool // value 为 nil 时删除对应的key，默认为将value设置为 nil
+}
+
+// DeepMerge 递归合并多个 map[string]any，返回新的map，不会修改参数。
+//
+// 两边都是 map[string]any 时递归合并，否则后面的value覆盖前面的value，适用于 JSON、YAML 解码后的数据，如依次合并默认配置、环境配置和覆盖配置。
+func DeepMerge(maps ...map[string]any) map[string]any {
+	return DeepMergeWithOptions(DeepMergeOptions{}, maps...)
+}
+
+// DeepMergeWithOptions 按 opts 递归合并多个 map[string]any，返回新的map，不会修改参数。
+func DeepMergeWithOptions(opts DeepMergeOptions, maps ...map[string]any) map[string]any {
+	result := make(map[string]any)
+
+	for _, m := range maps {
+		deepMergeInto(result, m, opts)
+	}
+
+	return result
+}
+
+// 将 src 合并到 dst 中，dst 中的 map 和 slice 均为复制后的值，可以直接修改
+func deepMergeInto(dst, src map[string]any, opts DeepMergeOptions) {
+	for k, v := range src {
+		if v == nil && opts.NilDeletes {
+			delete(dst, k)
+			continue
+		}
+
+		switch sv := v.(type) {
+		case map[string]any:
+			dm, ok := dst[k].(map[string]any)
+			if !ok {
+				dm = make(map[string]any, len(sv))
+			}
+			deepMergeInto(dm, sv, opts)
+			dst[k] = dm
+
+		case []any:
+			if ds, ok := dst[k].([]any); ok && opts.AppendSlices {
+				dst[k] = append(ds, deepCopySlice(sv, opts)...)
+			} else {
+				dst[k] = deepCopySlice(sv, opts)
+			}
+
+		default:
+			dst[k] = v
+		}
+	}
+}
+
+func deepCopySlice(s []any, opts DeepMergeOptions) []any {
+	result := make([]any, len(s))
+
+	for i, v := range s {
+		switch sv := v.(type) {
+		case map[string]any:
+			m := make(map[string]any, len(sv))
+			deepMergeInto(m, sv, opts)
+			result[i] = m
+		case []any:
+			result[i] = deepCopySlice(sv, opts)
+		default:
+			result[i] = v
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,66 @@
+package mapjez
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func decodeTestJSON(s string) map[string]any {
+	var m map[string]any
+	if err := json.Unmarshal([]byte(s), &m); err != nil {
+		panic(err)
+	}
+	return m
+}
+
+func TestDeepMerge(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	defaults := decodeTestJSON(`{"name":"app","db":{"host":"localhost","port":5432,"opts":{"ssl":false}},"tags":["a"]}`)
+	env := decodeTestJSON(`{"db":{"host":"db.prod","opts":{"ssl":true}},"tags":["b"],"debug":null}`)
+	override := decodeTestJSON(`{"db":{"port":6432},"name":{"short":"a"}}`)
+
+	result := DeepMerge(defaults, env, override)
+
+	ass.Equal(decodeTestJSON(`{
+		"name":{"short":"a"},
+		"db":{"host":"db.prod","port":6432,"opts":{"ssl":true}},
+		"tags":["b"],
+		"debug":null
+	}`), result)
+
+	// 参数不会被修改
+	ass.Equal(decodeTestJSON(`{"name":"app","db":{"host":"localhost","port":5432,"opts":{"ssl":false}},"tags":["a"]}`), defaults)
+
+	result["db"].(map[string]any)["host"] = "changed"
+	result["tags"].([]any)[0] = "changed"
+
+	ass.Equal("db.prod", env["db"].(map[string]any)["host"])
+	ass.Equal("b", env["tags"].([]any)[0])
+
+	ass.Equal(map[string]any{}, DeepMerge())
+}
+
+func TestDeepMergeWithOptions(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	m1 := decodeTestJSON(`{"tags":["a"],"db":{"host":"localhost","port":5432},"debug":true}`)
+	m2 := decodeTestJSON(`{"tags":["b",{"c":1,"d":null}],"db":{"port":null},"debug":null,"x":null}`)
+
+	ass.Equal(decodeTestJSON(`{"tags":["a","b",{"c":1}],"db":{"host":"localhost"}}`), DeepMergeWithOptions(DeepMergeOptions{
+		AppendSlices: true,
+		NilDeletes:   true,
+	}, m1, m2))
+
+	ass.Equal(decodeTestJSON(`{"tags":["b",{"c":1,"d":null}],"db":{"host":"localhost","port":null},"debug":null,"x":null}`), DeepMergeWithOptions(DeepMergeOptions{}, m1, m2))
+
+	// 追加后不会影响参数
+	result := DeepMergeWithOptions(DeepMergeOptions{AppendSlices: true}, m1, m2)
+	result["tags"].([]any)[0] = "changed"
+
+	ass.Equal([]any{"a"}, m1["tags"])
+}
@@ -0,0 +1,99 @@
+package mapjez
+
+import "reflect"
+
+// Change 变化前后的value。
+type Change[V any] struct {
+	Old V
+	New V
+}
+
+// MapDiff 两个map之间的差异。
+type MapDiff[K comparable, V any] struct {
+	Added   map[K]V         // 新增的元素
+	Removed map[K]V         // 删除的元素
+	Changed map[K]Change[V] // value发生变化的元素
+}
+
+// IsEmpty 判断是否没有差异。
+func (d MapDiff[K, V]) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+func newMapDiff[K comparable, V any]() MapDiff[K, V] {
+	return MapDiff[K, V]{
+		Added:   make(map[K]V),
+		Removed: make(map[K]V),
+		Changed: make(map[K]Change[V]),
+	}
+}
+
+// Diff 比较 old 和 new 两个map，返回新增、删除和value发生变化的元素。
+func Diff[K, V comparable](old, new map[K]V) MapDiff[K, V] {
+	return DiffBy(old, new, func(a, b V) bool {
+		return a == b
+	})
+}
+
+// DiffBy 比较 old 和 new 两个map，使用 equal 函数判断value是否相等，返回新增、删除和value发生变化的元素。
+func DiffBy[K comparable, V any](old, new map[K]V, equal func(a, b V) bool) MapDiff[K, V] {
+	result := newMapDiff[K, V]()
+
+	for k, ov := range old {
+		nv, ok := new[k]
+		if !ok {
+			result.Removed[k] = ov
+			continue
+		}
+
+		if !equal(ov, nv) {
+			result.Changed[k] = Change[V]{Old: ov, New: nv}
+		}
+	}
+
+	for k, nv := range new {
+		if _, ok := old[k]; !ok {
+			result.Added[k] = nv
+		}
+	}
+
+	return result
+}
+
+// DeepDiff 递归比较 old 和 new 两个 map[string]any，key为以 "." 分隔的路径，如 "db.host"。
+//
+// 两边都是 map[string]any 时递归比较，否则使用 reflect.DeepEqual 比较，只存在于一边的子map作为一个整体报告。
+func DeepDiff(old, new map[string]any) MapDiff[string, any] {
+	result := newMapDiff[string, any]()
+	deepDiff("", old, new, result)
+	return result
+}
+
+func deepDiff(prefix string, old, new map[string]any, result MapDiff[string, any]) {
+	for k, ov := range old {
+		path := prefix + k
+
+		nv, ok := new[k]
+		if !ok {
+			result.Removed[path] = ov
+			continue
+		}
+
+		om, ok1 := ov.(map[string]any)
+		nm, ok2 := nv.(map[string]any)
+		if ok1 && ok2 {
+			deepDiff(path+".", om, nm, result)
+			continue
+		}
+
+		if !reflect.DeepEqual(ov, nv) {
+			result.Changed[path] = Change[any]{Old: ov, New: nv}
+		}
+	}
+
+	for k, nv := range new {
+		if _, ok := old[k]; !ok {
+			result.Added[prefix+k] = nv
+		}
+	}
+}
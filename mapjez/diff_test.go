@@ -0,0 +1,71 @@
+package mapjez
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	old := map[string]int{"a": 1, "b": 2, "c": 3}
+	new := map[string]int{"b": 2, "c": 4, "d": 5}
+
+	d := Diff(old, new)
+
+	ass.Equal(map[string]int{"d": 5}, d.Added)
+	ass.Equal(map[string]int{"a": 1}, d.Removed)
+	ass.Equal(map[string]Change[int]{"c": {Old: 3, New: 4}}, d.Changed)
+	ass.False(d.IsEmpty())
+
+	ass.True(Diff(old, old).IsEmpty())
+	ass.True(Diff[string, int](nil, nil).IsEmpty())
+
+	d = Diff(nil, old)
+	ass.Equal(old, d.Added)
+	ass.Empty(d.Removed)
+}
+
+func TestDiffBy(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	old := map[string][]string{"a": {"x"}, "b": {"Y"}}
+	new := map[string][]string{"a": {"X"}, "b": {"y", "z"}}
+
+	d := DiffBy(old, new, func(a, b []string) bool {
+		return strings.EqualFold(strings.Join(a, ","), strings.Join(b, ","))
+	})
+
+	ass.Empty(d.Added)
+	ass.Empty(d.Removed)
+	ass.Equal(map[string]Change[[]string]{"b": {Old: []string{"Y"}, New: []string{"y", "z"}}}, d.Changed)
+}
+
+func TestDeepDiff(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	old := decodeTestJSON(`{"name":"app","db":{"host":"localhost","port":5432,"opts":{"ssl":false}},"tags":["a"],"cache":{"ttl":1}}`)
+	new := decodeTestJSON(`{"name":"app","db":{"host":"db.prod","opts":{"ssl":false,"timeout":3}},"tags":["a","b"],"cache":1,"log":{"level":"info"}}`)
+
+	d := DeepDiff(old, new)
+
+	ass.Equal(map[string]any{
+		"db.opts.timeout": float64(3),
+		"log":             map[string]any{"level": "info"},
+	}, d.Added)
+
+	ass.Equal(map[string]any{"db.port": float64(5432)}, d.Removed)
+
+	ass.Equal(map[string]Change[any]{
+		"db.host": {Old: "localhost", New: "db.prod"},
+		"tags":    {Old: []any{"a"}, New: []any{"a", "b"}},
+		"cache":   {Old: map[string]any{"ttl": float64(1)}, New: float64(1)},
+	}, d.Changed)
+
+	ass.True(DeepDiff(old, decodeTestJSON(`{"name":"app","db":{"host":"localhost","port":5432,"opts":{"ssl":false}},"tags":["a"],"cache":{"ttl":1}}`)).IsEmpty())
+}
@@ -1,6 +1,20 @@
 // Package mapjez map相关函数
 package mapjez
 
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Entry map中的一个键值对。
+type Entry[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
 // ForEach 遍历map，对每个元素调用 iteratee 函数。
 func ForEach[K comparable, V any](m map[K]V, iteratee func(key K, value V)) {
 	for k, v := range m {
@@ -168,3 +182,397 @@ func MapToSliceFilter[K comparable, V any, R any](m map[K]V, iteratee func(key K
 
 	return result
 }
+
+// SortedKeys 返回map中所有的key，按升序排列。
+func SortedKeys[K constraints.Ordered, V any](m map[K]V) []K {
+	keys := Keys(m)
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// SortedKeysBy 返回map中所有的key，按 less 函数排序，less 需要对任意两个不同的key给出确定的顺序，否则结果顺序不确定。
+func SortedKeysBy[K comparable, V any](m map[K]V, less func(a, b K) bool) []K {
+	keys := Keys(m)
+	sort.Slice(keys, func(i, j int) bool {
+		return less(keys[i], keys[j])
+	})
+	return keys
+}
+
+// SortedKeysAndValues 返回map中所有的key和value，按key升序排列，values[i] 为 keys[i] 对应的value。
+func SortedKeysAndValues[K constraints.Ordered, V any](m map[K]V) ([]K, []V) {
+	keys := SortedKeys(m)
+	values := make([]V, 0, len(keys))
+
+	for _, k := range keys {
+		values = append(values, m[k])
+	}
+
+	return keys, values
+}
+
+// ForEachSorted 按key升序遍历map，对每个元素调用 iteratee 函数。
+func ForEachSorted[K constraints.Ordered, V any](m map[K]V, iteratee func(key K, value V)) {
+	for _, k := range SortedKeys(m) {
+		iteratee(k, m[k])
+	}
+}
+
+// ForEachSortedBy 按 less 函数排序后的key顺序遍历map，对每个元素调用 iteratee 函数。
+func ForEachSortedBy[K comparable, V any](m map[K]V, less func(a, b K) bool, iteratee func(key K, value V)) {
+	for _, k := range SortedKeysBy(m, less) {
+		iteratee(k, m[k])
+	}
+}
+
+// Entries 返回map中所有的键值对，顺序不确定。
+func Entries[K comparable, V any](m map[K]V) []Entry[K, V] {
+	result := make([]Entry[K, V], 0, len(m))
+
+	for k, v := range m {
+		result = append(result, Entry[K, V]{Key: k, Value: v})
+	}
+
+	return result
+}
+
+// SortedEntries 返回map中所有的键值对，按key升序排列。
+func SortedEntries[K constraints.Ordered, V any](m map[K]V) []Entry[K, V] {
+	result := make([]Entry[K, V], 0, len(m))
+
+	for _, k := range SortedKeys(m) {
+		result = append(result, Entry[K, V]{Key: k, Value: m[k]})
+	}
+
+	return result
+}
+
+// SortedEntriesBy 返回map中所有的键值对，按 less 函数排序，可以同时比较key和value。
+//
+// less 判断为相等的键值对之间顺序不确定，如需确定的顺序，应在 less 中比较key作为最后的排序条件。
+func SortedEntriesBy[K comparable, V any](m map[K]V, less func(a, b Entry[K, V]) bool) []Entry[K, V] {
+	result := Entries(m)
+	sort.Slice(result, func(i, j int) bool {
+		return less(result[i], result[j])
+	})
+	return result
+}
+
+// Merge 合并多个map，返回新的map，key相同时后面的value覆盖前面的value。
+func Merge[K comparable, V any](maps ...map[K]V) map[K]V {
+	return MergeBy(nil, maps...)
+}
+
+// MergeBy 合并多个map，返回新的map，key相同时调用 resolver 函数，existing 为已合并的value，incoming 为当前map中的value，返回值作为合并后的value。
+//
+// resolver 为 nil 时与 Merge 相同。
+func MergeBy[K comparable, V any](resolver func(key K, existing, incoming V) V, maps ...map[K]V) map[K]V {
+	size := 0
+	for _, m := range maps {
+		size += len(m)
+	}
+
+	result := make(map[K]V, size)
+
+	for _, m := range maps {
+		for k, v := range m {
+			if existing, ok := result[k]; ok && resolver != nil {
+				v = resolver(k, existing, v)
+			}
+			result[k] = v
+		}
+	}
+
+	return result
+}
+
+// Invert 交换map的key和value，如果多个key对应同一个value，保留的key不确定，需要确定的结果时使用 InvertBy 或 InvertGroup。
+func Invert[K, V comparable](m map[K]V) map[V]K {
+	result := make(map[V]K, len(m))
+
+	for k, v := range m {
+		result[v] = k
+	}
+
+	return result
+}
+
+// InvertBy 交换map的key和value，如果多个key对应同一个value，调用 resolver 函数，existing 为已保留的key，incoming 为当前的key，返回值作为保留的key。
+func InvertBy[K, V comparable](m map[K]V, resolver func(value V, existing, incoming K) K) map[V]K {
+	result := make(map[V]K, len(m))
+
+	for k, v := range m {
+		if existing, ok := result[v]; ok {
+			k = resolver(v, existing, k)
+		}
+		result[v] = k
+	}
+
+	return result
+}
+
+// InvertGroup 交换map的key和value，对应同一个value的所有key组成切片，切片中key的顺序不确定。
+func InvertGroup[K, V comparable](m map[K]V) map[V][]K {
+	result := make(map[V][]K)
+
+	for k, v := range m {
+		result[v] = append(result[v], k)
+	}
+
+	return result
+}
+
+// GroupBy 遍历切片，对每个元素调用 iteratee 函数，按返回的key分组，每组中的元素保持原有顺序。
+func GroupBy[T any, K comparable](s []T, iteratee func(item T) K) map[K][]T {
+	result := make(map[K][]T)
+
+	for _, v := range s {
+		k := iteratee(v)
+		result[k] = append(result[k], v)
+	}
+
+	return result
+}
+
+// CountBy 遍历切片，对每个元素调用 iteratee 函数，统计每个key出现的次数。
+func CountBy[T any, K comparable](s []T, iteratee func(item T) K) map[K]int {
+	result := make(map[K]int)
+
+	for _, v := range s {
+		result[iteratee(v)]++
+	}
+
+	return result
+}
+
+// Partition 遍历map，对每个元素调用 predicate 函数，返回 true 的元素放入 matched，其余放入 rest。
+func Partition[K comparable, V any](m map[K]V, predicate func(key K, value V) bool) (matched, rest map[K]V) {
+	matched = make(map[K]V)
+	rest = make(map[K]V)
+
+	for k, v := range m {
+		if predicate(k, v) {
+			matched[k] = v
+		} else {
+			rest[k] = v
+		}
+	}
+
+	return matched, rest
+}
+
+// Reduce 遍历map，对每个元素调用 fn 函数，acc 为上一次调用的返回值，第一次调用时为 init，返回最后一次调用的结果，遍历顺序不确定。
+func Reduce[K comparable, V any, R any](m map[K]V, init R, fn func(acc R, key K, value V) R) R {
+	acc := init
+
+	for k, v := range m {
+		acc = fn(acc, k, v)
+	}
+
+	return acc
+}
+
+// SumValues 返回map中所有value的和。
+func SumValues[K comparable, V constraints.Integer | constraints.Float](m map[K]V) V {
+	var sum V
+
+	for _, v := range m {
+		sum += v
+	}
+
+	return sum
+}
+
+// MinBy 返回 less 函数判断为最小的键值对，map为空时 ok 为 false，多个最小值时返回的键值对不确定。
+func MinBy[K comparable, V any](m map[K]V, less func(a, b V) bool) (key K, value V, ok bool) {
+	for k, v := range m {
+		if !ok || less(v, value) {
+			key, value, ok = k, v, true
+		}
+	}
+
+	return
+}
+
+// MaxBy 返回 less 函数判断为最大的键值对，map为空时 ok 为 false，多个最大值时返回的键值对不确定。
+func MaxBy[K comparable, V any](m map[K]V, less func(a, b V) bool) (key K, value V, ok bool) {
+	for k, v := range m {
+		if !ok || less(value, v) {
+			key, value, ok = k, v, true
+		}
+	}
+
+	return
+}
+
+// AnyMatch 判断是否有任意一个元素使 predicate 返回 true，map为空时返回 false。
+func AnyMatch[K comparable, V any](m map[K]V, predicate func(key K, value V) bool) bool {
+	for k, v := range m {
+		if predicate(k, v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AllMatch 判断是否所有元素都使 predicate 返回 true，map为空时返回 true。
+func AllMatch[K comparable, V any](m map[K]V, predicate func(key K, value V) bool) bool {
+	for k, v := range m {
+		if !predicate(k, v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Count 返回使 predicate 返回 true 的元素数量。
+func Count[K comparable, V any](m map[K]V, predicate func(key K, value V) bool) int {
+	n := 0
+
+	for k, v := range m {
+		if predicate(k, v) {
+			n++
+		}
+	}
+
+	return n
+}
+
+// TopN 返回value最大的 n 个键值对，按value降序排列，value相同时按key升序排列，n 大于map长度时返回所有键值对。
+func TopN[K, V constraints.Ordered](m map[K]V, n int) []Entry[K, V] {
+	if n <= 0 {
+		return []Entry[K, V]{}
+	}
+
+	result := SortedEntriesBy(m, func(a, b Entry[K, V]) bool {
+		if a.Value != b.Value {
+			return a.Value > b.Value
+		}
+		return a.Key < b.Key
+	})
+
+	if n < len(result) {
+		result = result[:n]
+	}
+
+	return result
+}
+
+// ErrKeyCollision 转换后的key重复。
+var ErrKeyCollision = errors.New("mapjez: key collision")
+
+// MapKeys 遍历map，对每个元素调用 iteratee 函数，使用返回值作为新的key，value不变。
+//
+// 多个元素转换后的key相同时保留的value不确定，需要确定的结果时使用 MapKeysBy 或 MapKeysErr。
+func MapKeys[K comparable, V any, K2 comparable](m map[K]V, iteratee func(key K, value V) K2) map[K2]V {
+	result := make(map[K2]V, len(m))
+
+	for k, v := range m {
+		result[iteratee(k, v)] = v
+	}
+
+	return result
+}
+
+// MapKeysBy 遍历map，对每个元素调用 iteratee 函数，使用返回值作为新的key，多个元素转换后的key相同时调用 resolver 函数，
+// existing 为已保留的value，incoming 为当前的value，返回值作为保留的value。
+func MapKeysBy[K comparable, V any, K2 comparable](m map[K]V, iteratee func(key K, value V) K2, resolver func(key K2, existing, incoming V) V) map[K2]V {
+	result := make(map[K2]V, len(m))
+
+	for k, v := range m {
+		k2 := iteratee(k, v)
+		if existing, ok := result[k2]; ok {
+			v = resolver(k2, existing, v)
+		}
+		result[k2] = v
+	}
+
+	return result
+}
+
+// MapKeysErr 遍历map，对每个元素调用 iteratee 函数，使用返回值作为新的key，iteratee 返回错误时立即停止并返回该错误，
+// 多个元素转换后的key相同时返回 ErrKeyCollision。
+func MapKeysErr[K comparable, V any, K2 comparable](m map[K]V, iteratee func(key K, value V) (K2, error)) (map[K2]V, error) {
+	result := make(map[K2]V, len(m))
+
+	for k, v := range m {
+		k2, err := iteratee(k, v)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := result[k2]; ok {
+			return nil, fmt.Errorf("%w: %v", ErrKeyCollision, k2)
+		}
+
+		result[k2] = v
+	}
+
+	return result, nil
+}
+
+// MapValues 遍历map，对每个元素调用 iteratee 函数，使用返回值作为新的value，key不变。
+func MapValues[K comparable, V any, V2 any](m map[K]V, iteratee func(key K, value V) V2) map[K]V2 {
+	result := make(map[K]V2, len(m))
+
+	for k, v := range m {
+		result[k] = iteratee(k, v)
+	}
+
+	return result
+}
+
+// MapValuesErr 遍历map，对每个元素调用 iteratee 函数，使用返回值作为新的value，iteratee 返回错误时立即停止并返回该错误。
+func MapValuesErr[K comparable, V any, V2 any](m map[K]V, iteratee func(key K, value V) (V2, error)) (map[K]V2, error) {
+	result := make(map[K]V2, len(m))
+
+	for k, v := range m {
+		v2, err := iteratee(k, v)
+		if err != nil {
+			return nil, err
+		}
+
+		result[k] = v2
+	}
+
+	return result, nil
+}
+
+// MapEntries 遍历map，对每个元素调用 iteratee 函数，使用返回值作为新的key和value。
+//
+// 多个元素转换后的key相同时保留的value不确定，需要确定的结果时使用 MapEntriesErr。
+func MapEntries[K comparable, V any, K2 comparable, V2 any](m map[K]V, iteratee func(key K, value V) (K2, V2)) map[K2]V2 {
+	result := make(map[K2]V2, len(m))
+
+	for k, v := range m {
+		k2, v2 := iteratee(k, v)
+		result[k2] = v2
+	}
+
+	return result
+}
+
+// MapEntriesErr 遍历map，对每个元素调用 iteratee 函数，使用返回值作为新的key和value，iteratee 返回错误时立即停止并返回该错误，
+// 多个元素转换后的key相同时返回 ErrKeyCollision。
+func MapEntriesErr[K comparable, V any, K2 comparable, V2 any](m map[K]V, iteratee func(key K, value V) (K2, V2, error)) (map[K2]V2, error) {
+	result := make(map[K2]V2, len(m))
+
+	for k, v := range m {
+		k2, v2, err := iteratee(k, v)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := result[k2]; ok {
+			return nil, fmt.Errorf("%w: %v", ErrKeyCollision, k2)
+		}
+
+		result[k2] = v2
+	}
+
+	return result, nil
+}
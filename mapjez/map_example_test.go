@@ -1,9 +1,11 @@
 package mapjez
 
 import (
+	"errors"
 	"fmt"
 	"sort"
 	"strconv"
+	"strings"
 )
 
 func ExampleForEach() {
@@ -323,3 +325,726 @@ func ExampleReplaceValue() {
 	// Output:
 	// map[a:222 b:2 c:3 d:4 e:5 f:222 g:222]
 }
+
+func ExampleSortedKeys() {
+
+	m := map[string]int{
+		"c": 3,
+		"a": 1,
+		"b": 2,
+	}
+
+	fmt.Println(SortedKeys(m))
+
+	// Output:
+	// [a b c]
+}
+
+func ExampleSortedKeysBy() {
+
+	m := map[string]int{
+		"c": 3,
+		"a": 1,
+		"b": 2,
+	}
+
+	fmt.Println(SortedKeysBy(m, func(a, b string) bool {
+		return a > b
+	}))
+
+	// Output:
+	// [c b a]
+}
+
+func ExampleSortedKeysAndValues() {
+
+	m := map[string]int{
+		"c": 3,
+		"a": 1,
+		"b": 2,
+	}
+
+	fmt.Println(SortedKeysAndValues(m))
+
+	// Output:
+	// [a b c] [1 2 3]
+}
+
+func ExampleForEachSorted() {
+
+	m := map[string]int{
+		"c": 3,
+		"a": 1,
+		"b": 2,
+	}
+
+	ForEachSorted(m, func(key string, value int) {
+		fmt.Println(key, value)
+	})
+
+	// Output:
+	// a 1
+	// b 2
+	// c 3
+}
+
+func ExampleForEachSortedBy() {
+
+	m := map[string]int{
+		"c": 3,
+		"a": 1,
+		"b": 2,
+	}
+
+	ForEachSortedBy(m, func(a, b string) bool {
+		return a > b
+	}, func(key string, value int) {
+		fmt.Println(key, value)
+	})
+
+	// Output:
+	// c 3
+	// b 2
+	// a 1
+}
+
+func ExampleEntries() {
+
+	m := map[string]int{
+		"a": 1,
+	}
+
+	fmt.Println(Entries(m))
+
+	// Output:
+	// [{a 1}]
+}
+
+func ExampleSortedEntries() {
+
+	m := map[string]int{
+		"c": 3,
+		"a": 1,
+		"b": 2,
+	}
+
+	fmt.Println(SortedEntries(m))
+
+	// Output:
+	// [{a 1} {b 2} {c 3}]
+}
+
+func ExampleSortedEntriesBy() {
+
+	m := map[string]int{
+		"a": 2,
+		"b": 1,
+		"c": 2,
+	}
+
+	fmt.Println(SortedEntriesBy(m, func(x, y Entry[string, int]) bool {
+		if x.Value != y.Value {
+			return x.Value > y.Value
+		}
+		return x.Key < y.Key
+	}))
+
+	// Output:
+	// [{a 2} {c 2} {b 1}]
+}
+
+func ExampleMerge() {
+
+	m1 := map[string]int{"a": 1, "b": 2}
+	m2 := map[string]int{"b": 3, "c": 4}
+
+	fmt.Println(Merge(m1, m2))
+
+	// Output:
+	// map[a:1 b:3 c:4]
+}
+
+func ExampleMergeBy() {
+
+	m1 := map[string]int{"a": 1, "b": 2}
+	m2 := map[string]int{"b": 3, "c": 4}
+
+	fmt.Println(MergeBy(func(key string, existing, incoming int) int {
+		return existing + incoming
+	}, m1, m2))
+
+	// Output:
+	// map[a:1 b:5 c:4]
+}
+
+func ExampleDeepMerge() {
+
+	defaults := map[string]any{
+		"db":   map[string]any{"host": "localhost", "port": 5432},
+		"tags": []any{"a"},
+	}
+
+	override := map[string]any{
+		"db":   map[string]any{"host": "db.prod"},
+		"tags": []any{"b"},
+	}
+
+	fmt.Println(DeepMerge(defaults, override))
+
+	// Output:
+	// map[db:map[host:db.prod port:5432] tags:[b]]
+}
+
+func ExampleDeepMergeWithOptions() {
+
+	defaults := map[string]any{
+		"db":   map[string]any{"host": "localhost", "port": 5432},
+		"tags": []any{"a"},
+	}
+
+	override := map[string]any{
+		"db":   map[string]any{"port": nil},
+		"tags": []any{"b"},
+	}
+
+	fmt.Println(DeepMergeWithOptions(DeepMergeOptions{AppendSlices: true, NilDeletes: true}, defaults, override))
+
+	// Output:
+	// map[db:map[host:localhost] tags:[a b]]
+}
+
+func ExampleDiff() {
+
+	old := map[string]int{"a": 1, "b": 2, "c": 3}
+	new := map[string]int{"b": 2, "c": 4, "d": 5}
+
+	d := Diff(old, new)
+
+	fmt.Println(d.Added)
+	fmt.Println(d.Removed)
+	fmt.Println(d.Changed)
+
+	// Output:
+	// map[d:5]
+	// map[a:1]
+	// map[c:{3 4}]
+}
+
+func ExampleDiffBy() {
+
+	old := map[string]float64{"a": 1.0, "b": 2.0}
+	new := map[string]float64{"a": 1.05, "b": 3.0}
+
+	d := DiffBy(old, new, func(x, y float64) bool {
+		return x-y < 0.1 && y-x < 0.1
+	})
+
+	fmt.Println(d.Changed)
+
+	// Output:
+	// map[b:{2 3}]
+}
+
+func ExampleDeepDiff() {
+
+	old := map[string]any{
+		"db": map[string]any{"host": "localhost", "port": 5432},
+	}
+
+	new := map[string]any{
+		"db":    map[string]any{"host": "db.prod"},
+		"debug": true,
+	}
+
+	d := DeepDiff(old, new)
+
+	fmt.Println(d.Added)
+	fmt.Println(d.Removed)
+	fmt.Println(d.Changed)
+
+	// Output:
+	// map[debug:true]
+	// map[db.port:5432]
+	// map[db.host:{localhost db.prod}]
+}
+
+func ExampleGetPath() {
+
+	m := map[string]any{
+		"db": map[string]any{
+			"hosts": []any{
+				map[string]any{"name": "a", "port": 5432.0},
+			},
+			"ssl": true,
+		},
+	}
+
+	fmt.Println(GetPath(m, "db.hosts[0].name"))
+	fmt.Println(GetPath(m, "db.hosts[1].name"))
+
+	// Output:
+	// a true
+	// <nil> false
+}
+
+func ExampleGetString() {
+
+	m := map[string]any{
+		"db": map[string]any{
+			"hosts": []any{
+				map[string]any{"name": "a", "port": 5432.0},
+			},
+			"ssl": true,
+		},
+	}
+
+	fmt.Println(GetString(m, "db.hosts[0].name"))
+
+	// Output:
+	// a true
+}
+
+func ExampleGetInt() {
+
+	m := map[string]any{
+		"db": map[string]any{
+			"hosts": []any{
+				map[string]any{"name": "a", "port": 5432.0},
+			},
+			"ssl": true,
+		},
+	}
+
+	fmt.Println(GetInt(m, "db.hosts[0].port"))
+
+	// Output:
+	// 5432 true
+}
+
+func ExampleGetFloat64() {
+
+	m := map[string]any{
+		"db": map[string]any{
+			"hosts": []any{
+				map[string]any{"name": "a", "port": 5432.0},
+			},
+			"ssl": true,
+		},
+	}
+
+	fmt.Println(GetFloat64(m, "db.hosts[0].port"))
+
+	// Output:
+	// 5432 true
+}
+
+func ExampleGetBool() {
+
+	m := map[string]any{
+		"db": map[string]any{
+			"hosts": []any{
+				map[string]any{"name": "a", "port": 5432.0},
+			},
+			"ssl": true,
+		},
+	}
+
+	fmt.Println(GetBool(m, "db.ssl"))
+
+	// Output:
+	// true true
+}
+
+func ExampleGetMap() {
+
+	m := map[string]any{
+		"db": map[string]any{
+			"hosts": []any{
+				map[string]any{"name": "a", "port": 5432.0},
+			},
+			"ssl": true,
+		},
+	}
+
+	fmt.Println(GetMap(m, "db.hosts[0]"))
+
+	// Output:
+	// map[name:a port:5432] true
+}
+
+func ExampleGetSlice() {
+
+	m := map[string]any{
+		"db": map[string]any{
+			"hosts": []any{
+				map[string]any{"name": "a", "port": 5432.0},
+			},
+			"ssl": true,
+		},
+	}
+
+	fmt.Println(GetSlice(m, "db.hosts"))
+
+	// Output:
+	// [map[name:a port:5432]] true
+}
+
+func ExampleSetPath() {
+
+	m := map[string]any{}
+
+	fmt.Println(SetPath(m, "db.hosts[1].name", "b"))
+	fmt.Println(m)
+
+	// Output:
+	// <nil>
+	// map[db:map[hosts:[<nil> map[name:b]]]]
+}
+
+func ExampleDeletePath() {
+
+	m := map[string]any{
+		"db": map[string]any{"hosts": []any{"a", "b"}, "ssl": true},
+	}
+
+	fmt.Println(DeletePath(m, "db.hosts[0]"))
+	fmt.Println(DeletePath(m, "db.ssl"))
+	fmt.Println(m)
+
+	// Output:
+	// true
+	// true
+	// map[db:map[hosts:[b]]]
+}
+
+func ExampleFlatten() {
+
+	m := map[string]any{
+		"db": map[string]any{"hosts": []any{"a", "b"}, "ssl": true},
+	}
+
+	fmt.Println(Flatten(m))
+
+	// Output:
+	// map[db.hosts[0]:a db.hosts[1]:b db.ssl:true]
+}
+
+func ExampleUnflatten() {
+
+	fmt.Println(Unflatten(map[string]any{
+		"db.hosts[0]": "a",
+		"db.ssl":      true,
+	}))
+
+	// Output:
+	// map[db:map[hosts:[a] ssl:true]] <nil>
+}
+
+func ExampleStructToMap() {
+
+	type User struct {
+		Name  string   `json:"name"`
+		Age   int      `json:"age,omitempty"`
+		Tags  []string `json:"tags"`
+		Token string   `json:"-"`
+	}
+
+	m, err := StructToMap(User{Name: "bob", Tags: []string{"a"}, Token: "x"})
+	fmt.Println(m, err)
+
+	// Output:
+	// map[name:bob tags:[a]] <nil>
+}
+
+func ExampleStructToMapWithOptions() {
+
+	type Query struct {
+		Name string `db:"name"`
+		Age  int    `db:"age,omitempty"`
+	}
+
+	m, err := StructToMapWithOptions(Query{Name: "bob"}, StructOptions{TagName: "db"})
+	fmt.Println(m, err)
+
+	// Output:
+	// map[name:bob] <nil>
+}
+
+func ExampleMapToStruct() {
+
+	type User struct {
+		Name  string   `json:"name"`
+		Age   int      `json:"age,omitempty"`
+		Tags  []string `json:"tags"`
+		Token string   `json:"-"`
+	}
+
+	var u User
+	err := MapToStruct(map[string]any{"name": "bob", "age": "18", "tags": []any{"a"}}, &u)
+	fmt.Printf("%+v %v\n", u, err)
+
+	err = MapToStruct(map[string]any{"age": 1.5}, &u)
+	fmt.Println(err)
+
+	// Output:
+	// {Name:bob Age:18 Tags:[a] Token:} <nil>
+	// mapjez: 1 field error(s) occurred:
+	// 	age: value 1.5 is not an integer
+}
+
+func ExampleMapToStructWithOptions() {
+
+	type Query struct {
+		Name string `db:"name"`
+		Age  int    `db:"age"`
+	}
+
+	var q Query
+	err := MapToStructWithOptions(map[string]any{"name": "bob", "age": 18.0}, &q, StructOptions{TagName: "db"})
+	fmt.Printf("%+v %v\n", q, err)
+
+	// Output:
+	// {Name:bob Age:18} <nil>
+}
+
+func ExampleInvert() {
+
+	fmt.Println(Invert(map[string]int{"a": 1, "b": 2}))
+
+	// Output:
+	// map[1:a 2:b]
+}
+
+func ExampleInvertBy() {
+
+	m := map[string]int{"b": 1, "a": 1, "c": 2}
+
+	fmt.Println(InvertBy(m, func(value int, existing, incoming string) string {
+		if incoming < existing {
+			return incoming
+		}
+		return existing
+	}))
+
+	// Output:
+	// map[1:a 2:c]
+}
+
+func ExampleInvertGroup() {
+
+	result := InvertGroup(map[string]int{"b": 1, "a": 1, "c": 2})
+	sort.Strings(result[1])
+
+	fmt.Println(result)
+
+	// Output:
+	// map[1:[a b] 2:[c]]
+}
+
+func ExampleGroupBy() {
+
+	fmt.Println(GroupBy([]int{1, 2, 3, 4, 5}, func(item int) bool {
+		return item%2 == 0
+	}))
+
+	// Output:
+	// map[false:[1 3 5] true:[2 4]]
+}
+
+func ExampleCountBy() {
+
+	fmt.Println(CountBy([]string{"a", "bb", "c"}, func(item string) int {
+		return len(item)
+	}))
+
+	// Output:
+	// map[1:2 2:1]
+}
+
+func ExamplePartition() {
+
+	matched, rest := Partition(map[string]int{"a": 1, "b": 2, "c": 3}, func(key string, value int) bool {
+		return value > 1
+	})
+
+	fmt.Println(matched, rest)
+
+	// Output:
+	// map[b:2 c:3] map[a:1]
+}
+
+func ExampleReduce() {
+
+	m := map[string]int{"a": 1, "bb": 2, "ccc": 3}
+
+	fmt.Println(Reduce(m, 0, func(acc int, key string, value int) int {
+		return acc + len(key)*value
+	}))
+
+	// Output:
+	// 14
+}
+
+func ExampleSumValues() {
+
+	fmt.Println(SumValues(map[string]int64{"a": 1, "b": 2, "c": 3}))
+
+	// Output:
+	// 6
+}
+
+func ExampleMinBy() {
+
+	fmt.Println(MinBy(map[string]int{"a": 3, "b": 1, "c": 2}, func(a, b int) bool {
+		return a < b
+	}))
+
+	// Output:
+	// b 1 true
+}
+
+func ExampleMaxBy() {
+
+	fmt.Println(MaxBy(map[string]int{"a": 3, "b": 1, "c": 2}, func(a, b int) bool {
+		return a < b
+	}))
+
+	// Output:
+	// a 3 true
+}
+
+func ExampleAnyMatch() {
+
+	fmt.Println(AnyMatch(map[string]int{"a": 1, "b": 2}, func(key string, value int) bool {
+		return value%2 == 0
+	}))
+
+	// Output:
+	// true
+}
+
+func ExampleAllMatch() {
+
+	fmt.Println(AllMatch(map[string]int{"a": 1, "b": 2}, func(key string, value int) bool {
+		return value%2 == 0
+	}))
+
+	// Output:
+	// false
+}
+
+func ExampleCount() {
+
+	fmt.Println(Count(map[string]int{"a": 1, "b": 2, "c": 3}, func(key string, value int) bool {
+		return value > 1
+	}))
+
+	// Output:
+	// 2
+}
+
+func ExampleTopN() {
+
+	m := map[string]int64{"a": 5, "b": 9, "c": 5, "d": 1}
+
+	fmt.Println(TopN(m, 3))
+
+	// Output:
+	// [{b 9} {a 5} {c 5}]
+}
+
+func ExampleMapKeys() {
+
+	fmt.Println(MapKeys(map[int]string{1: "a", 2: "b"}, func(key int, value string) string {
+		return "k" + strconv.Itoa(key)
+	}))
+
+	// Output:
+	// map[k1:a k2:b]
+}
+
+func ExampleMapKeysBy() {
+
+	m := map[string]int{"a": 1, "A": 2, "b": 3}
+
+	fmt.Println(MapKeysBy(m, func(key string, value int) string {
+		return strings.ToLower(key)
+	}, func(key string, existing, incoming int) int {
+		return existing + incoming
+	}))
+
+	// Output:
+	// map[a:3 b:3]
+}
+
+func ExampleMapKeysErr() {
+
+	fmt.Println(MapKeysErr(map[string]int{"1": 1, "2": 2}, func(key string, value int) (int, error) {
+		return strconv.Atoi(key)
+	}))
+
+	// Output:
+	// map[1:1 2:2] <nil>
+}
+
+func ExampleMapValues() {
+
+	fmt.Println(MapValues(map[string]int{"a": 1, "b": 2}, func(key string, value int) string {
+		return key + strconv.Itoa(value)
+	}))
+
+	// Output:
+	// map[a:a1 b:b2]
+}
+
+func ExampleMapValuesErr() {
+
+	_, err := MapValuesErr(map[string]string{"a": "x"}, func(key string, value string) (int, error) {
+		return strconv.Atoi(value)
+	})
+
+	fmt.Println(err)
+
+	// Output:
+	// strconv.Atoi: parsing "x": invalid syntax
+}
+
+func ExampleMapEntries() {
+
+	fmt.Println(MapEntries(map[string]int{"a": 1, "b": 2}, func(key string, value int) (int, string) {
+		return value, key
+	}))
+
+	// Output:
+	// map[1:a 2:b]
+}
+
+func ExampleMapEntriesErr() {
+
+	_, err := MapEntriesErr(map[string]int{"a": 1, "b": 2}, func(key string, value int) (string, int, error) {
+		return "x", value, nil
+	})
+
+	fmt.Println(errors.Is(err, ErrKeyCollision))
+
+	// Output:
+	// true
+}
+
+func ExampleSetToSortedSlice() {
+
+	fmt.Println(SetToSortedSlice(NewSet(3, 1, 2)))
+
+	// Output:
+	// [1 2 3]
+}
+
+func ExampleSetToSortedSliceBy() {
+
+	fmt.Println(SetToSortedSliceBy(NewSet(3, 1, 2), func(a, b int) bool {
+		return a > b
+	}))
+
+	// Output:
+	// [3 2 1]
+}
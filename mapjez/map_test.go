@@ -1,8 +1,10 @@
 package mapjez
 
 import (
+	"errors"
 	"sort"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -317,3 +319,437 @@ func TestReplaceValue(t *testing.T) {
 		"g": 222,
 	}, m)
 }
+
+func TestSortedKeys(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+	m := map[string]int{
+		"c": 3,
+		"a": 1,
+		"e": 5,
+		"b": 2,
+		"d": 4,
+	}
+
+	ass.Equal([]string{"a", "b", "c", "d", "e"}, SortedKeys(m))
+	ass.Equal([]string{}, SortedKeys(map[string]int{}))
+}
+
+func TestSortedKeysBy(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	type key struct {
+		a int
+		b string
+	}
+
+	m := map[key]int{
+		{2, "a"}: 1,
+		{1, "b"}: 2,
+		{1, "a"}: 3,
+	}
+
+	ass.Equal([]key{{1, "a"}, {1, "b"}, {2, "a"}}, SortedKeysBy(m, func(x, y key) bool {
+		if x.a != y.a {
+			return x.a < y.a
+		}
+		return x.b < y.b
+	}))
+}
+
+func TestSortedKeysAndValues(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+	m := map[string]int{
+		"c": 3,
+		"a": 1,
+		"b": 2,
+	}
+
+	keys, values := SortedKeysAndValues(m)
+
+	ass.Equal([]string{"a", "b", "c"}, keys)
+	ass.Equal([]int{1, 2, 3}, values)
+}
+
+func TestForEachSorted(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+	m := map[int]string{
+		3: "c",
+		1: "a",
+		2: "b",
+	}
+
+	var values []string
+
+	ForEachSorted(m, func(key int, value string) {
+		values = append(values, value)
+	})
+
+	ass.Equal([]string{"a", "b", "c"}, values)
+}
+
+func TestForEachSortedBy(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+	m := map[int]string{
+		3: "c",
+		1: "a",
+		2: "b",
+	}
+
+	var values []string
+
+	ForEachSortedBy(m, func(a, b int) bool {
+		return a > b
+	}, func(key int, value string) {
+		values = append(values, value)
+	})
+
+	ass.Equal([]string{"c", "b", "a"}, values)
+}
+
+func TestEntries(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+	m := map[string]int{
+		"a": 1,
+		"b": 2,
+	}
+
+	ass.ElementsMatch([]Entry[string, int]{{"a", 1}, {"b", 2}}, Entries(m))
+}
+
+func TestSortedEntries(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+	m := map[string]int{
+		"b": 2,
+		"a": 1,
+		"c": 3,
+	}
+
+	ass.Equal([]Entry[string, int]{{"a", 1}, {"b", 2}, {"c", 3}}, SortedEntries(m))
+}
+
+func TestSortedEntriesBy(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+	m := map[string]int{
+		"a": 2,
+		"b": 1,
+		"c": 2,
+		"d": 3,
+	}
+
+	// 按 value 降序，value 相同时按 key 升序
+	ass.Equal([]Entry[string, int]{{"d", 3}, {"a", 2}, {"c", 2}, {"b", 1}}, SortedEntriesBy(m, func(x, y Entry[string, int]) bool {
+		if x.Value != y.Value {
+			return x.Value > y.Value
+		}
+		return x.Key < y.Key
+	}))
+}
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	m1 := map[string]int{"a": 1, "b": 2}
+	m2 := map[string]int{"b": 3, "c": 4}
+
+	ass.Equal(map[string]int{"a": 1, "b": 3, "c": 4}, Merge(m1, m2, nil))
+	ass.Equal(map[string]int{}, Merge[string, int]())
+
+	// 参数不会被修改
+	ass.Equal(map[string]int{"a": 1, "b": 2}, m1)
+}
+
+func TestMergeBy(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	m1 := map[string]int{"a": 1, "b": 2}
+	m2 := map[string]int{"b": 3, "c": 4}
+	m3 := map[string]int{"b": 5, "c": 6}
+
+	var keys []string
+
+	ass.Equal(map[string]int{"a": 1, "b": 10, "c": 10}, MergeBy(func(key string, existing, incoming int) int {
+		keys = append(keys, key)
+		return existing + incoming
+	}, m1, m2, m3))
+
+	sort.Strings(keys)
+	ass.Equal([]string{"b", "b", "c"}, keys)
+
+	ass.Equal(map[string]int{"a": 1, "b": 3, "c": 4}, MergeBy(nil, m1, m2))
+}
+
+func TestInvert(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	ass.Equal(map[int]string{1: "a", 2: "b"}, Invert(map[string]int{"a": 1, "b": 2}))
+
+	v := Invert(map[string]int{"a": 1, "b": 1})
+	ass.Len(v, 1)
+	ass.Contains([]string{"a", "b"}, v[1])
+}
+
+func TestInvertBy(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	m := map[string]int{"b": 1, "a": 1, "c": 1, "d": 2}
+
+	// 保留最小的key
+	ass.Equal(map[int]string{1: "a", 2: "d"}, InvertBy(m, func(value int, existing, incoming string) string {
+		if incoming < existing {
+			return incoming
+		}
+		return existing
+	}))
+}
+
+func TestInvertGroup(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	result := InvertGroup(map[string]int{"b": 1, "a": 1, "c": 2})
+
+	ass.Len(result, 2)
+	ass.ElementsMatch([]string{"a", "b"}, result[1])
+	ass.Equal([]string{"c"}, result[2])
+}
+
+func TestGroupBy(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	ass.Equal(map[bool][]int{true: {2, 4}, false: {1, 3, 5}}, GroupBy([]int{1, 2, 3, 4, 5}, func(item int) bool {
+		return item%2 == 0
+	}))
+
+	ass.Equal(map[bool][]int{}, GroupBy([]int{}, func(item int) bool {
+		return item%2 == 0
+	}))
+}
+
+func TestCountBy(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	ass.Equal(map[int]int{1: 2, 2: 1, 3: 1}, CountBy([]string{"a", "bb", "c", "ddd"}, func(item string) int {
+		return len(item)
+	}))
+}
+
+func TestPartition(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	matched, rest := Partition(map[string]int{"a": 1, "b": 2, "c": 3}, func(key string, value int) bool {
+		return value > 1
+	})
+
+	ass.Equal(map[string]int{"b": 2, "c": 3}, matched)
+	ass.Equal(map[string]int{"a": 1}, rest)
+
+	matched, rest = Partition(map[string]int(nil), func(key string, value int) bool {
+		return true
+	})
+
+	ass.Empty(matched)
+	ass.NotNil(rest)
+}
+
+func TestReduce(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	m := map[string]int{"a": 1, "bb": 2, "ccc": 3}
+
+	ass.Equal(14, Reduce(m, 0, func(acc int, key string, value int) int {
+		return acc + len(key)*value
+	}))
+
+	ass.Equal("x", Reduce(map[string]int{}, "x", func(acc string, key string, value int) string {
+		return acc + key
+	}))
+}
+
+func TestSumValues(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	ass.Equal(int64(6), SumValues(map[string]int64{"a": 1, "b": 2, "c": 3}))
+	ass.Equal(1.5, SumValues(map[string]float64{"a": 1, "b": 0.5}))
+	ass.Equal(0, SumValues(map[string]int{}))
+}
+
+func TestMinByMaxBy(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	m := map[string]int{"a": 3, "b": 1, "c": 2}
+
+	less := func(a, b int) bool {
+		return a < b
+	}
+
+	k, v, ok := MinBy(m, less)
+	ass.True(ok)
+	ass.Equal("b", k)
+	ass.Equal(1, v)
+
+	k, v, ok = MaxBy(m, less)
+	ass.True(ok)
+	ass.Equal("a", k)
+	ass.Equal(3, v)
+
+	_, _, ok = MinBy(map[string]int{}, less)
+	ass.False(ok)
+
+	_, _, ok = MaxBy(map[string]int{}, less)
+	ass.False(ok)
+}
+
+func TestMatch(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+
+	positive := func(key string, value int) bool {
+		return value > 0
+	}
+
+	even := func(key string, value int) bool {
+		return value%2 == 0
+	}
+
+	ass.True(AnyMatch(m, even))
+	ass.False(AnyMatch(map[string]int{}, positive))
+
+	ass.True(AllMatch(m, positive))
+	ass.False(AllMatch(m, even))
+	ass.True(AllMatch(map[string]int{}, even))
+
+	ass.Equal(1, Count(m, even))
+	ass.Equal(3, Count(m, positive))
+}
+
+func TestTopN(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	m := map[string]int64{"a": 5, "b": 9, "c": 5, "d": 1, "e": 9}
+
+	ass.Equal([]Entry[string, int64]{{"b", 9}, {"e", 9}, {"a", 5}}, TopN(m, 3))
+	ass.Len(TopN(m, 10), 5)
+	ass.Empty(TopN(m, 0))
+	ass.Empty(TopN(map[string]int64{}, 3))
+}
+
+func TestMapKeys(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	m := map[int]string{1: "a", 2: "b"}
+
+	ass.Equal(map[string]string{"1": "a", "2": "b"}, MapKeys(m, func(key int, value string) string {
+		return strconv.Itoa(key)
+	}))
+
+	result := MapKeys(m, func(key int, value string) bool {
+		return true
+	})
+	ass.Len(result, 1)
+}
+
+func TestMapKeysBy(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	m := map[string]int{"a": 1, "A": 2, "b": 3}
+
+	ass.Equal(map[string]int{"a": 3, "b": 3}, MapKeysBy(m, func(key string, value int) string {
+		return strings.ToLower(key)
+	}, func(key string, existing, incoming int) int {
+		return existing + incoming
+	}))
+}
+
+func TestMapKeysErr(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	m := map[string]int{"1": 1, "2": 2}
+
+	result, err := MapKeysErr(m, func(key string, value int) (int, error) {
+		return strconv.Atoi(key)
+	})
+	ass.Nil(err)
+	ass.Equal(map[int]int{1: 1, 2: 2}, result)
+
+	_, err = MapKeysErr(map[string]int{"x": 1}, func(key string, value int) (int, error) {
+		return strconv.Atoi(key)
+	})
+	ass.True(errors.Is(err, strconv.ErrSyntax))
+
+	_, err = MapKeysErr(m, func(key string, value int) (int, error) {
+		return 0, nil
+	})
+	ass.True(errors.Is(err, ErrKeyCollision))
+}
+
+func TestMapValues(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	ass.Equal(map[string]string{"a": "a1", "b": "b2"}, MapValues(map[string]int{"a": 1, "b": 2}, func(key string, value int) string {
+		return key + strconv.Itoa(value)
+	}))
+
+	result, err := MapValuesErr(map[string]string{"a": "1", "b": "2"}, func(key string, value string) (int, error) {
+		return strconv.Atoi(value)
+	})
+	ass.Nil(err)
+	ass.Equal(map[string]int{"a": 1, "b": 2}, result)
+
+	_, err = MapValuesErr(map[string]string{"a": "1", "b": "x"}, func(key string, value string) (int, error) {
+		return strconv.Atoi(value)
+	})
+	ass.True(errors.Is(err, strconv.ErrSyntax))
+}
+
+func TestMapEntries(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	m := map[string]int{"a": 1, "b": 2}
+
+	ass.Equal(map[int]string{1: "a", 2: "b"}, MapEntries(m, func(key string, value int) (int, string) {
+		return value, key
+	}))
+
+	result, err := MapEntriesErr(m, func(key string, value int) (string, bool, error) {
+		return strings.ToUpper(key), value > 1, nil
+	})
+	ass.Nil(err)
+	ass.Equal(map[string]bool{"A": false, "B": true}, result)
+
+	_, err = MapEntriesErr(m, func(key string, value int) (string, int, error) {
+		return "", 0, errors.New("failed")
+	})
+	ass.EqualError(err, "failed")
+
+	_, err = MapEntriesErr(m, func(key string, value int) (string, int, error) {
+		return "x", value, nil
+	})
+	ass.True(errors.Is(err, ErrKeyCollision))
+	ass.Contains(err.Error(), ": x")
+}
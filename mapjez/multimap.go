@@ -0,0 +1,128 @@
+package mapjez
+
+// MultiMap 一个key对应多个value的map，同一个key下的value不重复，按第一次添加的顺序排列，非并发安全。
+//
+// 添加、删除value的时间复杂度均为 O(1)，需要使用 NewMultiMap 创建。
+type MultiMap[K, V comparable] struct {
+	m    map[K]*OrderedMap[V, struct{}]
+	size int
+}
+
+// NewMultiMap 创建一个一个key对应多个value的map。
+func NewMultiMap[K, V comparable]() *MultiMap[K, V] {
+	return &MultiMap[K, V]{
+		m: make(map[K]*OrderedMap[V, struct{}]),
+	}
+}
+
+// Add 为 key 添加多个value，已存在的value会被忽略，返回新添加的数量。
+func (mm *MultiMap[K, V]) Add(key K, values ...V) int {
+	set, ok := mm.m[key]
+	if !ok {
+		if len(values) == 0 {
+			return 0
+		}
+		set = NewOrderedMap[V, struct{}]()
+		mm.m[key] = set
+	}
+
+	n := set.Len()
+	for _, v := range values {
+		set.Set(v, struct{}{})
+	}
+	n = set.Len() - n
+
+	mm.size += n
+
+	return n
+}
+
+// Remove 删除 key 下的多个value，key 下没有value时删除 key，返回删除的数量。
+func (mm *MultiMap[K, V]) Remove(key K, values ...V) int {
+	set, ok := mm.m[key]
+	if !ok {
+		return 0
+	}
+
+	n := set.Len()
+	set.Deletes(values...)
+	n -= set.Len()
+
+	mm.size -= n
+
+	if set.Len() == 0 {
+		delete(mm.m, key)
+	}
+
+	return n
+}
+
+// Deletes 删除多个key及其所有value。
+func (mm *MultiMap[K, V]) Deletes(keys ...K) {
+	for _, k := range keys {
+		if set, ok := mm.m[k]; ok {
+			mm.size -= set.Len()
+			delete(mm.m, k)
+		}
+	}
+}
+
+// Get 返回 key 对应的所有value，按添加顺序排列，key 不存在时返回 nil。
+func (mm *MultiMap[K, V]) Get(key K) []V {
+	set, ok := mm.m[key]
+	if !ok {
+		return nil
+	}
+	return set.Keys()
+}
+
+// Has 判断 key 下是否存在 value。
+func (mm *MultiMap[K, V]) Has(key K, value V) bool {
+	set, ok := mm.m[key]
+	return ok && set.Has(value)
+}
+
+// HasKey 判断 key 是否存在。
+func (mm *MultiMap[K, V]) HasKey(key K) bool {
+	_, ok := mm.m[key]
+	return ok
+}
+
+// Len 返回key的数量。
+func (mm *MultiMap[K, V]) Len() int {
+	return len(mm.m)
+}
+
+// Size 返回所有value的数量。
+func (mm *MultiMap[K, V]) Size() int {
+	return mm.size
+}
+
+// Keys 返回所有的key，顺序不确定。
+func (mm *MultiMap[K, V]) Keys() []K {
+	result := make([]K, 0, len(mm.m))
+
+	for k := range mm.m {
+		result = append(result, k)
+	}
+
+	return result
+}
+
+// ForEach 遍历所有的key，对每个key调用 iteratee 函数，key 的顺序不确定，values 按添加顺序排列。
+func (mm *MultiMap[K, V]) ForEach(iteratee func(key K, values []V)) {
+	for k, set := range mm.m {
+		iteratee(k, set.Keys())
+	}
+}
+
+// ToMap 返回普通map，每个key对应的value按添加顺序排列。
+func (mm *MultiMap[K, V]) ToMap() map[K][]V {
+	result := make(map[K][]V, len(mm.m))
+
+	for k, set := range mm.m {
+		result[k] = set.Keys()
+	}
+
+	return result
+}
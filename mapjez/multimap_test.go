@@ -0,0 +1,81 @@
+package mapjez
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiMap_Add(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	mm := NewMultiMap[string, int]()
+
+	ass.Equal(3, mm.Add("a", 3, 1, 2))
+	ass.Equal(1, mm.Add("a", 1, 4, 4))
+	ass.Equal(1, mm.Add("b", 1))
+	ass.Equal(0, mm.Add("c"))
+
+	ass.Equal([]int{3, 1, 2, 4}, mm.Get("a"))
+	ass.Equal([]int{1}, mm.Get("b"))
+	ass.Nil(mm.Get("c"))
+
+	ass.True(mm.Has("a", 4))
+	ass.False(mm.Has("b", 4))
+	ass.False(mm.Has("c", 1))
+
+	ass.True(mm.HasKey("a"))
+	ass.False(mm.HasKey("c"))
+
+	ass.Equal(2, mm.Len())
+	ass.Equal(5, mm.Size())
+	ass.ElementsMatch([]string{"a", "b"}, mm.Keys())
+}
+
+func TestMultiMap_Remove(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	mm := NewMultiMap[string, int]()
+	mm.Add("a", 1, 2, 3)
+	mm.Add("b", 1)
+	mm.Add("c", 1, 2)
+
+	ass.Equal(2, mm.Remove("a", 1, 3, 5))
+	ass.Equal([]int{2}, mm.Get("a"))
+
+	// 没有value时删除key
+	ass.Equal(1, mm.Remove("b", 1))
+	ass.False(mm.HasKey("b"))
+
+	ass.Equal(0, mm.Remove("d", 1))
+
+	mm.Deletes("c", "d")
+
+	ass.Equal(map[string][]int{"a": {2}}, mm.ToMap())
+	ass.Equal(1, mm.Len())
+	ass.Equal(1, mm.Size())
+
+	// 删除后重新添加排在最后
+	mm.Add("a", 1, 2)
+	ass.Equal([]int{2, 1}, mm.Get("a"))
+}
+
+func TestMultiMap_ForEach(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	mm := NewMultiMap[string, int]()
+	mm.Add("a", 2, 1)
+	mm.Add("b", 3)
+
+	result := make(map[string][]int)
+
+	mm.ForEach(func(key string, values []int) {
+		result[key] = values
+	})
+
+	ass.Equal(map[string][]int{"a": {2, 1}, "b": {3}}, result)
+	ass.Equal(result, mm.ToMap())
+}
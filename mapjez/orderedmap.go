@@ -0,0 +1,358 @@
+package mapjez
+
+import (
+	"bytes"
+	"container/list"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// OrderedMap 按插入顺序遍历的map，Get、Set、Delete 的时间复杂度均为 O(1)，非并发安全。
+//
+// 更新已存在的key时不会改变其位置，零值可以直接使用。
+type OrderedMap[K comparable, V any] struct {
+	m map[K]*list.Element
+	l *list.List
+}
+
+// NewOrderedMap 创建一个按插入顺序遍历的map。
+func NewOrderedMap[K comparable, V any]() *OrderedMap[K, V] {
+	return &OrderedMap[K, V]{
+		m: make(map[K]*list.Element),
+		l: list.New(),
+	}
+}
+
+// NewOrderedMapFromEntries 按 entries 的顺序创建一个按插入顺序遍历的map，重复的key保留第一次出现的位置和最后一次出现的value。
+func NewOrderedMapFromEntries[K comparable, V any](entries []Entry[K, V]) *OrderedMap[K, V] {
+	om := NewOrderedMap[K, V]()
+	for _, e := range entries {
+		om.Set(e.Key, e.Value)
+	}
+	return om
+}
+
+// 延迟初始化，使零值可以直接使用
+func (om *OrderedMap[K, V]) lazyInit() {
+	if om.m == nil {
+		om.m = make(map[K]*list.Element)
+		om.l = list.New()
+	}
+}
+
+// 返回第一个元素，未初始化时返回 nil
+func (om *OrderedMap[K, V]) front() *list.Element {
+	if om.l == nil {
+		return nil
+	}
+	return om.l.Front()
+}
+
+func entryOf[K comparable, V any](e *list.Element) *Entry[K, V] {
+	return e.Value.(*Entry[K, V])
+}
+
+// Get 返回 key 对应的value，如果不存在，ok 为 false。
+func (om *OrderedMap[K, V]) Get(key K) (value V, ok bool) {
+	e, ok := om.m[key]
+	if !ok {
+		return
+	}
+	return entryOf[K, V](e).Value, true
+}
+
+// Has 判断 key 是否存在。
+func (om *OrderedMap[K, V]) Has(key K) bool {
+	_, ok := om.m[key]
+	return ok
+}
+
+// Set 设置 key 对应的value，如果 key 不存在，则添加到末尾，否则只更新value，位置不变。
+func (om *OrderedMap[K, V]) Set(key K, value V) {
+	if e, ok := om.m[key]; ok {
+		entryOf[K, V](e).Value = value
+		return
+	}
+
+	om.lazyInit()
+	om.m[key] = om.l.PushBack(&Entry[K, V]{Key: key, Value: value})
+}
+
+// Deletes 通过key删除多个元素。
+func (om *OrderedMap[K, V]) Deletes(keys ...K) {
+	for _, k := range keys {
+		if e, ok := om.m[k]; ok {
+			om.l.Remove(e)
+			delete(om.m, k)
+		}
+	}
+}
+
+// MoveToFront 将 key 移动到最前面，如果 key 不存在，返回 false。
+func (om *OrderedMap[K, V]) MoveToFront(key K) bool {
+	e, ok := om.m[key]
+	if ok {
+		om.l.MoveToFront(e)
+	}
+	return ok
+}
+
+// MoveToBack 将 key 移动到最后面，如果 key 不存在，返回 false。
+func (om *OrderedMap[K, V]) MoveToBack(key K) bool {
+	e, ok := om.m[key]
+	if ok {
+		om.l.MoveToBack(e)
+	}
+	return ok
+}
+
+// Front 返回第一个键值对，如果map为空，ok 为 false。
+func (om *OrderedMap[K, V]) Front() (entry Entry[K, V], ok bool) {
+	if e := om.front(); e != nil {
+		return *entryOf[K, V](e), true
+	}
+	return
+}
+
+// Back 返回最后一个键值对，如果map为空，ok 为 false。
+func (om *OrderedMap[K, V]) Back() (entry Entry[K, V], ok bool) {
+	if om.l == nil {
+		return
+	}
+
+	if e := om.l.Back(); e != nil {
+		return *entryOf[K, V](e), true
+	}
+	return
+}
+
+// Len 返回map的长度。
+func (om *OrderedMap[K, V]) Len() int {
+	return len(om.m)
+}
+
+// ForEach 按顺序遍历map，对每个元素调用 iteratee 函数，iteratee 中不能修改map。
+func (om *OrderedMap[K, V]) ForEach(iteratee func(key K, value V)) {
+	for e := om.front(); e != nil; e = e.Next() {
+		entry := entryOf[K, V](e)
+		iteratee(entry.Key, entry.Value)
+	}
+}
+
+// ForEachWithBreak 按顺序遍历map，对每个元素调用 iteratee 函数，如果返回 false，则停止遍历，iteratee 中不能修改map。
+func (om *OrderedMap[K, V]) ForEachWithBreak(iteratee func(key K, value V) bool) {
+	for e := om.front(); e != nil; e = e.Next() {
+		entry := entryOf[K, V](e)
+		if !iteratee(entry.Key, entry.Value) {
+			break
+		}
+	}
+}
+
+// Filter 按顺序遍历map，对每个元素调用 iteratee 函数，如果 iteratee 返回 true，则将该元素添加到结果map中，结果保持原有顺序。
+func (om *OrderedMap[K, V]) Filter(iteratee func(key K, value V) bool) *OrderedMap[K, V] {
+	result := NewOrderedMap[K, V]()
+
+	om.ForEach(func(key K, value V) {
+		if iteratee(key, value) {
+			result.Set(key, value)
+		}
+	})
+
+	return result
+}
+
+// Keys 按顺序返回所有的key。
+func (om *OrderedMap[K, V]) Keys() []K {
+	result := make([]K, 0, om.Len())
+
+	om.ForEach(func(key K, _ V) {
+		result = append(result, key)
+	})
+
+	return result
+}
+
+// Values 按顺序返回所有的value。
+func (om *OrderedMap[K, V]) Values() []V {
+	result := make([]V, 0, om.Len())
+
+	om.ForEach(func(_ K, value V) {
+		result = append(result, value)
+	})
+
+	return result
+}
+
+// Entries 按顺序返回所有的键值对。
+func (om *OrderedMap[K, V]) Entries() []Entry[K, V] {
+	result := make([]Entry[K, V], 0, om.Len())
+
+	om.ForEach(func(key K, value V) {
+		result = append(result, Entry[K, V]{Key: key, Value: value})
+	})
+
+	return result
+}
+
+// ToMap 返回普通map，不保留顺序，可以配合 Filter、MapToSliceBy 等函数使用。
+func (om *OrderedMap[K, V]) ToMap() map[K]V {
+	result := make(map[K]V, om.Len())
+
+	om.ForEach(func(key K, value V) {
+		result[key] = value
+	})
+
+	return result
+}
+
+// OrderedMapToSliceBy 按顺序遍历 OrderedMap，对每个元素调用 iteratee 函数，并返回调用后结果切片。
+func OrderedMapToSliceBy[K comparable, V any, R any](om *OrderedMap[K, V], iteratee func(key K, value V) R) []R {
+	result := make([]R, 0, om.Len())
+
+	om.ForEach(func(key K, value V) {
+		result = append(result, iteratee(key, value))
+	})
+
+	return result
+}
+
+// MarshalJSON 实现 json.Marshaler，按顺序输出 JSON 对象。
+//
+// key 支持字符串、整数类型及实现了 encoding.TextMarshaler 的类型，与 encoding/json 对 map key 的要求一致。
+//
+// 使用值接收者，作为结构体中的非指针字段时也可以正确输出。
+func (om OrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+
+	for e := om.front(); e != nil; e = e.Next() {
+		entry := entryOf[K, V](e)
+
+		key, err := marshalKey(entry.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		if e.Prev() != nil {
+			buf.WriteByte(',')
+		}
+
+		// 字符串编码不会失败
+		b, _ := json.Marshal(key)
+		buf.Write(b)
+		buf.WriteByte(':')
+
+		if b, err = json.Marshal(entry.Value); err != nil {
+			return nil, err
+		}
+		buf.Write(b)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler，按 JSON 对象中key的顺序添加元素，原有元素会被清空。
+//
+// 按照 json.Unmarshaler 的约定，JSON 为 null 时不做任何修改，与 Set、SafeSet 相同（encoding/json 会将普通 map 置为 nil）。
+func (om *OrderedMap[K, V]) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		return nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("mapjez: cannot unmarshal %v into OrderedMap", tok)
+	}
+
+	om.m = make(map[K]*list.Element)
+	om.l = list.New()
+
+	for dec.More() {
+		if tok, err = dec.Token(); err != nil {
+			return err
+		}
+
+		var key K
+		if err = unmarshalKey(tok.(string), &key); err != nil {
+			return err
+		}
+
+		var value V
+		if err = dec.Decode(&value); err != nil {
+			return err
+		}
+
+		om.Set(key, value)
+	}
+
+	// 读取结尾的 '}'
+	_, err = dec.Token()
+	return err
+}
+
+// 将 key 转换为 JSON 对象的 key
+func marshalKey(key any) (string, error) {
+	v := reflect.ValueOf(key)
+
+	if v.Kind() == reflect.String {
+		return v.String(), nil
+	}
+
+	if tm, ok := key.(encoding.TextMarshaler); ok {
+		b, err := tm.MarshalText()
+		return string(b), err
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	}
+
+	return "", fmt.Errorf("mapjez: unsupported key type %T", key)
+}
+
+// 将 JSON 对象的 key 转换为 key
+func unmarshalKey(s string, key any) error {
+	if tu, ok := key.(encoding.TextUnmarshaler); ok {
+		return tu.UnmarshalText([]byte(s))
+	}
+
+	v := reflect.ValueOf(key).Elem()
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+		return nil
+	}
+
+	return fmt.Errorf("mapjez: unsupported key type %s", v.Type())
+}
@@ -0,0 +1,274 @@
+package mapjez
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func buildTestOrderedMap(keys ...string) *OrderedMap[string, int] {
+	om := NewOrderedMap[string, int]()
+	for i, k := range keys {
+		om.Set(k, i)
+	}
+
+	return om
+}
+
+func TestOrderedMap_Set(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	om := buildTestOrderedMap("c", "a", "b")
+
+	ass.Equal([]string{"c", "a", "b"}, om.Keys())
+	ass.Equal([]int{0, 1, 2}, om.Values())
+
+	// 更新不改变位置
+	om.Set("c", 10)
+
+	ass.Equal([]string{"c", "a", "b"}, om.Keys())
+
+	v, ok := om.Get("c")
+	ass.True(ok)
+	ass.Equal(10, v)
+
+	_, ok = om.Get("d")
+	ass.False(ok)
+
+	ass.True(om.Has("a"))
+	ass.False(om.Has("d"))
+	ass.Equal(3, om.Len())
+}
+
+func TestNewOrderedMapFromEntries(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	om := NewOrderedMapFromEntries([]Entry[string, int]{{"b", 1}, {"a", 2}, {"b", 3}})
+
+	ass.Equal([]Entry[string, int]{{"b", 3}, {"a", 2}}, om.Entries())
+}
+
+func TestOrderedMap_Deletes(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	om := buildTestOrderedMap("a", "b", "c", "d")
+
+	om.Deletes("b", "d", "e")
+
+	ass.Equal([]string{"a", "c"}, om.Keys())
+	ass.Equal(2, om.Len())
+
+	om.Set("b", 1)
+
+	ass.Equal([]string{"a", "c", "b"}, om.Keys())
+}
+
+func TestOrderedMap_Move(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	om := buildTestOrderedMap("a", "b", "c")
+
+	ass.True(om.MoveToFront("c"))
+	ass.Equal([]string{"c", "a", "b"}, om.Keys())
+
+	ass.True(om.MoveToBack("c"))
+	ass.Equal([]string{"a", "b", "c"}, om.Keys())
+
+	ass.False(om.MoveToFront("d"))
+	ass.False(om.MoveToBack("d"))
+
+	front, ok := om.Front()
+	ass.True(ok)
+	ass.Equal(Entry[string, int]{"a", 0}, front)
+
+	back, ok := om.Back()
+	ass.True(ok)
+	ass.Equal(Entry[string, int]{"c", 2}, back)
+
+	_, ok = NewOrderedMap[string, int]().Front()
+	ass.False(ok)
+
+	_, ok = NewOrderedMap[string, int]().Back()
+	ass.False(ok)
+}
+
+func TestOrderedMap_ForEachWithBreak(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	om := buildTestOrderedMap("a", "b", "c")
+
+	var keys []string
+
+	om.ForEachWithBreak(func(key string, value int) bool {
+		keys = append(keys, key)
+		return key != "b"
+	})
+
+	ass.Equal([]string{"a", "b"}, keys)
+}
+
+func TestOrderedMap_Filter(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	om := buildTestOrderedMap("d", "c", "b", "a")
+
+	ass.Equal([]string{"d", "b"}, om.Filter(func(key string, value int) bool {
+		return value%2 == 0
+	}).Keys())
+
+	ass.Equal(map[string]int{"a": 3, "b": 2, "c": 1, "d": 0}, om.ToMap())
+
+	ass.Equal([]string{"d0", "c1", "b2", "a3"}, OrderedMapToSliceBy(om, func(key string, value int) string {
+		return key + strconv.Itoa(value)
+	}))
+}
+
+func TestOrderedMap_JSON(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	om := buildTestOrderedMap("c", "a", "b")
+
+	b, err := json.Marshal(om)
+	ass.Nil(err)
+	ass.Equal(`{"c":0,"a":1,"b":2}`, string(b))
+
+	om2 := NewOrderedMap[string, int]()
+	ass.Error(json.Unmarshal([]byte(`{"z":1,"y":{"bad":1},"x":3}`), om2))
+
+	ass.Nil(json.Unmarshal([]byte(` {"z":1,"y":2,"x":3} `), om2))
+	ass.Equal([]string{"z", "y", "x"}, om2.Keys())
+	ass.Equal([]int{1, 2, 3}, om2.Values())
+
+	// 嵌套在结构体中
+	type config struct {
+		Fields *OrderedMap[string, []string] `json:"fields"`
+	}
+
+	var c config
+	ass.Nil(json.Unmarshal([]byte(`{"fields":{"b":["1"],"a":[]}}`), &c))
+	ass.Equal([]string{"b", "a"}, c.Fields.Keys())
+
+	b, _ = json.Marshal(c)
+	ass.Equal(`{"fields":{"b":["1"],"a":[]}}`, string(b))
+
+	ass.Error(json.Unmarshal([]byte(`[1]`), om2))
+
+	// null 不做任何修改
+	ass.Nil(json.Unmarshal([]byte(`null`), om2))
+	ass.Equal([]string{"z", "y", "x"}, om2.Keys())
+
+	var c2 struct {
+		M OrderedMap[string, int]
+	}
+	c2.M.Set("a", 1)
+	ass.Nil(json.Unmarshal([]byte(`{"M":null}`), &c2))
+	ass.Equal([]string{"a"}, c2.M.Keys())
+}
+
+func TestOrderedMap_ZeroValue(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	var om OrderedMap[string, int]
+
+	ass.Equal(0, om.Len())
+	ass.False(om.Has("a"))
+	ass.False(om.MoveToFront("a"))
+
+	_, ok := om.Front()
+	ass.False(ok)
+
+	_, ok = om.Back()
+	ass.False(ok)
+
+	ass.Equal([]string{}, om.Keys())
+	om.Deletes("a")
+
+	om.Set("b", 1)
+	om.Set("a", 2)
+	ass.Equal([]string{"b", "a"}, om.Keys())
+
+	// 作为结构体中的非指针字段
+	type config struct {
+		M OrderedMap[string, int]
+	}
+
+	b, err := json.Marshal(config{})
+	ass.Nil(err)
+	ass.Equal(`{"M":{}}`, string(b))
+
+	var c config
+	ass.Nil(json.Unmarshal([]byte(`{"M":{"y":1,"x":2}}`), &c))
+	ass.Equal([]string{"y", "x"}, c.M.Keys())
+
+	b, err = json.Marshal(c)
+	ass.Nil(err)
+	ass.Equal(`{"M":{"y":1,"x":2}}`, string(b))
+}
+
+type testTextKey struct {
+	a, b string
+}
+
+func (k testTextKey) MarshalText() ([]byte, error) {
+	return []byte(k.a + "-" + k.b), nil
+}
+
+func (k *testTextKey) UnmarshalText(text []byte) error {
+	a, b, ok := strings.Cut(string(text), "-")
+	if !ok {
+		return errors.New("invalid key")
+	}
+	k.a, k.b = a, b
+	return nil
+}
+
+func TestOrderedMap_JSONKey(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	om := NewOrderedMap[int, string]()
+	om.Set(2, "b")
+	om.Set(-1, "a")
+
+	b, err := json.Marshal(om)
+	ass.Nil(err)
+	ass.Equal(`{"2":"b","-1":"a"}`, string(b))
+
+	om2 := NewOrderedMap[uint8, string]()
+	ass.Nil(json.Unmarshal([]byte(`{"2":"b","1":"a"}`), om2))
+	ass.Equal([]uint8{2, 1}, om2.Keys())
+	ass.Error(json.Unmarshal([]byte(`{"256":"b"}`), om2))
+
+	// encoding.TextMarshaler
+	om3 := NewOrderedMap[testTextKey, int]()
+	om3.Set(testTextKey{"b", "1"}, 1)
+	om3.Set(testTextKey{"a", "2"}, 2)
+
+	b, err = json.Marshal(om3)
+	ass.Nil(err)
+	ass.Equal(`{"b-1":1,"a-2":2}`, string(b))
+
+	ass.Nil(json.Unmarshal(b, om3))
+	ass.Equal([]testTextKey{{"b", "1"}, {"a", "2"}}, om3.Keys())
+	ass.Error(json.Unmarshal([]byte(`{"err":1}`), om3))
+
+	// 不支持的key类型
+	om4 := NewOrderedMap[float64, int]()
+	om4.Set(1.5, 1)
+
+	_, err = json.Marshal(om4)
+	ass.Error(err)
+	ass.Error(json.Unmarshal([]byte(`{"1.5":1}`), om4))
+}
@@ -0,0 +1,442 @@
+package mapjez
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// 路径中的一段，key 或切片下标
+type pathSegment struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+func (s pathSegment) String() string {
+	if s.isIndex {
+		return "[" + strconv.Itoa(s.index) + "]"
+	}
+	return s.key
+}
+
+// 解析 "a.b[2].c" 格式的路径
+func parsePath(path string) ([]pathSegment, error) {
+	if path == "" {
+		return nil, fmt.Errorf("mapjez: empty path")
+	}
+
+	var segs []pathSegment
+
+	for i := 0; i < len(path); {
+		j := i
+		for j < len(path) && path[j] != '.' && path[j] != '[' {
+			j++
+		}
+
+		if j == i {
+			return nil, fmt.Errorf("mapjez: invalid path %q: empty key at offset %d", path, i)
+		}
+
+		segs = append(segs, pathSegment{key: path[i:j]})
+
+		for j < len(path) && path[j] == '[' {
+			end := strings.IndexByte(path[j:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("mapjez: invalid path %q: missing ']'", path)
+			}
+
+			n, err := strconv.Atoi(path[j+1 : j+end])
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("mapjez: invalid path %q: invalid index %q", path, path[j+1:j+end])
+			}
+
+			segs = append(segs, pathSegment{index: n, isIndex: true})
+			j += end + 1
+		}
+
+		if j < len(path) {
+			if path[j] != '.' {
+				return nil, fmt.Errorf("mapjez: invalid path %q: unexpected %q at offset %d", path, path[j], j)
+			}
+
+			j++
+
+			if j == len(path) {
+				return nil, fmt.Errorf("mapjez: invalid path %q: trailing '.'", path)
+			}
+		}
+
+		i = j
+	}
+
+	return segs, nil
+}
+
+// GetPath 按路径返回 m 中的value，路径格式为 "a.b[2].c"，"." 分隔map的key，"[n]" 为 []any 的下标，如果路径无效或不存在，ok 为 false。
+//
+// 适用于 JSON、YAML 解码后的数据，包含 "."、"[" 的key无法通过路径访问。
+func GetPath(m map[string]any, path string) (value any, ok bool) {
+	segs, err := parsePath(path)
+	if err != nil {
+		return nil, false
+	}
+
+	var cur any = m
+
+	for _, seg := range segs {
+		if seg.isIndex {
+			s, ok := cur.([]any)
+			if !ok || seg.index >= len(s) {
+				return nil, false
+			}
+			cur = s[seg.index]
+			continue
+		}
+
+		mm, ok := cur.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+
+		if cur, ok = mm[seg.key]; !ok {
+			return nil, false
+		}
+	}
+
+	return cur, true
+}
+
+// GetString 按路径返回字符串，如果不存在或不是字符串，ok 为 false。
+func GetString(m map[string]any, path string) (string, bool) {
+	v, ok := GetPath(m, path)
+	if !ok {
+		return "", false
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.String {
+		return "", false
+	}
+
+	return rv.String(), true
+}
+
+// GetInt 按路径返回整数，支持所有整数类型、json.Number 和没有小数部分的浮点数，超出 int 范围时 ok 为 false。
+//
+// JSON 解码后的数字默认为 float64，可以直接使用 GetInt 获取。
+func GetInt(m map[string]any, path string) (int, bool) {
+	v, ok := GetPath(m, path)
+	if !ok {
+		return 0, false
+	}
+
+	n, ok := toInt64(v)
+	if !ok || int64(int(n)) != n {
+		return 0, false
+	}
+
+	return int(n), true
+}
+
+// GetFloat64 按路径返回浮点数，支持所有整数、浮点数类型和 json.Number。
+func GetFloat64(m map[string]any, path string) (float64, bool) {
+	v, ok := GetPath(m, path)
+	if !ok {
+		return 0, false
+	}
+
+	return toFloat64(v)
+}
+
+// GetBool 按路径返回布尔值，如果不存在或不是布尔值，ok 为 false。
+func GetBool(m map[string]any, path string) (bool, bool) {
+	v, ok := GetPath(m, path)
+	if !ok {
+		return false, false
+	}
+
+	b, ok := v.(bool)
+	return b, ok
+}
+
+// GetMap 按路径返回 map[string]any，如果不存在或类型不匹配，ok 为 false。
+func GetMap(m map[string]any, path string) (map[string]any, bool) {
+	v, ok := GetPath(m, path)
+	if !ok {
+		return nil, false
+	}
+
+	mm, ok := v.(map[string]any)
+	return mm, ok
+}
+
+// GetSlice 按路径返回 []any，如果不存在或类型不匹配，ok 为 false。
+func GetSlice(m map[string]any, path string) ([]any, bool) {
+	v, ok := GetPath(m, path)
+	if !ok {
+		return nil, false
+	}
+
+	s, ok := v.([]any)
+	return s, ok
+}
+
+func toInt64(v any) (int64, bool) {
+	if n, ok := v.(json.Number); ok {
+		if i, err := n.Int64(); err == nil {
+			return i, true
+		}
+
+		f, err := n.Float64()
+		if err != nil {
+			return 0, false
+		}
+
+		return floatToInt64(f)
+	}
+
+	rv := reflect.ValueOf(v)
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u := rv.Uint()
+		if u > math.MaxInt64 {
+			return 0, false
+		}
+		return int64(u), true
+	case reflect.Float32, reflect.Float64:
+		return floatToInt64(rv.Float())
+	}
+
+	return 0, false
+}
+
+func toFloat64(v any) (float64, bool) {
+	if n, ok := v.(json.Number); ok {
+		f, err := n.Float64()
+		return f, err == nil
+	}
+
+	rv := reflect.ValueOf(v)
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+
+	return 0, false
+}
+
+func floatToInt64(f float64) (int64, bool) {
+	if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, false
+	}
+	return int64(f), true
+}
+
+// SetPath 按路径设置 m 中的value，路径格式与 GetPath 相同，不存在的中间节点会自动创建，key 创建为 map[string]any，下标创建为 []any，切片长度不足时用 nil 填充。
+//
+// 如果路径无效或中间节点的类型不匹配，返回错误，m 不会被修改。
+func SetPath(m map[string]any, path string, value any) error {
+	if m == nil {
+		return fmt.Errorf("mapjez: SetPath on nil map")
+	}
+
+	segs, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+
+	_, err = setPath(m, path, segs, 0, value)
+	return err
+}
+
+// 设置 cur 中 segs[i:] 对应的value，返回设置后的 cur
+func setPath(cur any, path string, segs []pathSegment, i int, value any) (any, error) {
+	if i == len(segs) {
+		return value, nil
+	}
+
+	seg := segs[i]
+
+	if seg.isIndex {
+		var s []any
+
+		switch v := cur.(type) {
+		case nil:
+		case []any:
+			s = v
+		default:
+			return nil, fmt.Errorf("mapjez: cannot set path %q: %s is %T, not []any", path, joinPath(segs[:i]), cur)
+		}
+
+		child := any(nil)
+		if seg.index < len(s) {
+			child = s[seg.index]
+		}
+
+		child, err := setPath(child, path, segs, i+1, value)
+		if err != nil {
+			return nil, err
+		}
+
+		for len(s) <= seg.index {
+			s = append(s, nil)
+		}
+		s[seg.index] = child
+
+		return s, nil
+	}
+
+	var m map[string]any
+
+	switch v := cur.(type) {
+	case nil:
+		m = make(map[string]any)
+	case map[string]any:
+		m = v
+	default:
+		return nil, fmt.Errorf("mapjez: cannot set path %q: %s is %T, not map[string]any", path, joinPath(segs[:i]), cur)
+	}
+
+	child, err := setPath(m[seg.key], path, segs, i+1, value)
+	if err != nil {
+		return nil, err
+	}
+
+	m[seg.key] = child
+
+	return m, nil
+}
+
+func joinPath(segs []pathSegment) string {
+	var b strings.Builder
+
+	for i, seg := range segs {
+		if i > 0 && !seg.isIndex {
+			b.WriteByte('.')
+		}
+		b.WriteString(seg.String())
+	}
+
+	return b.String()
+}
+
+// DeletePath 按路径删除 m 中的value，下标对应的元素会从切片中移除，后面的元素前移，返回是否删除成功。
+func DeletePath(m map[string]any, path string) bool {
+	segs, err := parsePath(path)
+	if err != nil {
+		return false
+	}
+
+	_, ok := deletePath(m, segs)
+	return ok
+}
+
+// 删除 cur 中 segs 对应的value，返回删除后的 cur
+func deletePath(cur any, segs []pathSegment) (any, bool) {
+	seg := segs[0]
+	last := len(segs) == 1
+
+	if seg.isIndex {
+		s, ok := cur.([]any)
+		if !ok || seg.index >= len(s) {
+			return cur, false
+		}
+
+		if last {
+			return append(s[:seg.index:seg.index], s[seg.index+1:]...), true
+		}
+
+		child, ok := deletePath(s[seg.index], segs[1:])
+		if ok {
+			s[seg.index] = child
+		}
+
+		return s, ok
+	}
+
+	m, ok := cur.(map[string]any)
+	if !ok {
+		return cur, false
+	}
+
+	v, ok := m[seg.key]
+	if !ok {
+		return cur, false
+	}
+
+	if last {
+		delete(m, seg.key)
+		return m, true
+	}
+
+	child, ok := deletePath(v, segs[1:])
+	if ok {
+		m[seg.key] = child
+	}
+
+	return m, ok
+}
+
+// Flatten 将嵌套的 map[string]any 展开为一层，key为 GetPath 格式的路径，如 "a.b[2].c"，空的map和切片作为value保留。
+func Flatten(m map[string]any) map[string]any {
+	result := make(map[string]any)
+
+	for k, v := range m {
+		flatten(k, v, result)
+	}
+
+	return result
+}
+
+func flatten(path string, v any, result map[string]any) {
+	switch vv := v.(type) {
+	case map[string]any:
+		if len(vv) == 0 {
+			result[path] = vv
+			return
+		}
+
+		for k, x := range vv {
+			flatten(path+"."+k, x, result)
+		}
+
+	case []any:
+		if len(vv) == 0 {
+			result[path] = vv
+			return
+		}
+
+		for i, x := range vv {
+			flatten(path+"["+strconv.Itoa(i)+"]", x, result)
+		}
+
+	default:
+		result[path] = v
+	}
+}
+
+// Unflatten 将 Flatten 展开的map还原为嵌套的 map[string]any，如果路径无效或相互冲突，如同时存在 "a" 和 "a.b"，返回错误。
+func Unflatten(m map[string]any) (map[string]any, error) {
+	result := make(map[string]any)
+
+	// 按路径排序，保证 "a" 在 "a.b" 之前，冲突时总是返回错误
+	for _, k := range SortedKeys(m) {
+		if err := SetPath(result, k, m[k]); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,243 @@
+package mapjez
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePath(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	segs, err := parsePath("a.b[2][0].c")
+	ass.Nil(err)
+	ass.Equal([]pathSegment{
+		{key: "a"},
+		{key: "b"},
+		{index: 2, isIndex: true},
+		{index: 0, isIndex: true},
+		{key: "c"},
+	}, segs)
+	ass.Equal("a.b[2][0].c", joinPath(segs))
+
+	for _, path := range []string{"", ".a", "a.", "a..b", "[0]", "a.[0]", "a[", "a[x]", "a[-1]", "a[0]b"} {
+		_, err = parsePath(path)
+		ass.Error(err, path)
+	}
+}
+
+func TestGetPath(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	m := decodeTestJSON(`{"a":{"b":[1,{"c":"x"},[true]]},"n":null}`)
+
+	v, ok := GetPath(m, "a.b[1].c")
+	ass.True(ok)
+	ass.Equal("x", v)
+
+	v, ok = GetPath(m, "a.b[2][0]")
+	ass.True(ok)
+	ass.Equal(true, v)
+
+	// value 为 nil 也存在
+	v, ok = GetPath(m, "n")
+	ass.True(ok)
+	ass.Nil(v)
+
+	for _, path := range []string{"x", "a.x", "a.b[3]", "a.b.c", "a[0]", "a.b[0].c", "a..b"} {
+		_, ok = GetPath(m, path)
+		ass.False(ok, path)
+	}
+
+	_, ok = GetPath(nil, "a")
+	ass.False(ok)
+}
+
+func TestGetTyped(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	m := decodeTestJSON(`{"s":"x","f":1.5,"i":42,"big":1e20,"b":true,"m":{"k":1},"l":[1]}`)
+	m["u8"] = uint8(7)
+	m["u64"] = uint64(math.MaxUint64)
+	m["num"] = json.Number("12")
+	m["numf"] = json.Number("1.0")
+	m["numbad"] = json.Number("x")
+
+	s, ok := GetString(m, "s")
+	ass.True(ok)
+	ass.Equal("x", s)
+
+	_, ok = GetString(m, "i")
+	ass.False(ok)
+
+	tests := []struct {
+		path string
+		want int
+		ok   bool
+	}{
+		{"i", 42, true},
+		{"u8", 7, true},
+		{"num", 12, true},
+		{"numf", 1, true},
+		{"f", 0, false},
+		{"big", 0, false},
+		{"u64", 0, false},
+		{"numbad", 0, false},
+		{"s", 0, false},
+		{"x", 0, false},
+	}
+
+	for _, tt := range tests {
+		n, ok := GetInt(m, tt.path)
+		ass.Equal(tt.ok, ok, tt.path)
+		ass.Equal(tt.want, n, tt.path)
+	}
+
+	f, ok := GetFloat64(m, "f")
+	ass.True(ok)
+	ass.Equal(1.5, f)
+
+	f, ok = GetFloat64(m, "u8")
+	ass.True(ok)
+	ass.Equal(7.0, f)
+
+	f, ok = GetFloat64(m, "num")
+	ass.True(ok)
+	ass.Equal(12.0, f)
+
+	_, ok = GetFloat64(m, "numbad")
+	ass.False(ok)
+
+	_, ok = GetFloat64(m, "s")
+	ass.False(ok)
+
+	b, ok := GetBool(m, "b")
+	ass.True(ok)
+	ass.True(b)
+
+	_, ok = GetBool(m, "s")
+	ass.False(ok)
+
+	mm, ok := GetMap(m, "m")
+	ass.True(ok)
+	ass.Equal(map[string]any{"k": 1.0}, mm)
+
+	_, ok = GetMap(m, "l")
+	ass.False(ok)
+
+	l, ok := GetSlice(m, "l")
+	ass.True(ok)
+	ass.Equal([]any{1.0}, l)
+
+	_, ok = GetSlice(m, "m")
+	ass.False(ok)
+}
+
+func TestSetPath(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	m := map[string]any{}
+
+	ass.Nil(SetPath(m, "a.b[2].c", 1))
+	ass.Equal(map[string]any{
+		"a": map[string]any{
+			"b": []any{nil, nil, map[string]any{"c": 1}},
+		},
+	}, m)
+
+	ass.Nil(SetPath(m, "a.b[0]", "x"))
+	ass.Nil(SetPath(m, "a.b[2].d", 2))
+	ass.Nil(SetPath(m, "a.b[3][1]", true))
+	ass.Nil(SetPath(m, "e", nil))
+
+	ass.Equal(map[string]any{
+		"a": map[string]any{
+			"b": []any{"x", nil, map[string]any{"c": 1, "d": 2}, []any{nil, true}},
+		},
+		"e": nil,
+	}, m)
+
+	// 类型不匹配时不会修改 m
+	err := SetPath(m, "a.b[0].c", 1)
+	ass.Error(err)
+	ass.True(strings.Contains(err.Error(), "a.b[0]"))
+
+	ass.Error(SetPath(m, "a[0]", 1))
+	ass.Error(SetPath(m, "a.b[3][1].c", 1))
+	ass.Error(SetPath(m, "a..b", 1))
+	ass.Error(SetPath(nil, "a", 1))
+
+	_, ok := GetPath(m, "x")
+	ass.False(ok)
+	ass.Len(m, 2)
+}
+
+func TestDeletePath(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	m := decodeTestJSON(`{"a":{"b":[1,{"c":"x","d":"y"},3]},"e":1}`)
+	b, _ := GetSlice(m, "a.b")
+
+	ass.True(DeletePath(m, "a.b[1].c"))
+	ass.True(DeletePath(m, "a.b[0]"))
+	ass.True(DeletePath(m, "e"))
+
+	ass.Equal(decodeTestJSON(`{"a":{"b":[{"d":"y"},3]}}`), m)
+
+	// 原切片不受影响
+	ass.Equal(1.0, b[0])
+
+	for _, path := range []string{"e", "a.b[2]", "a.x", "a.b.c", "a[0]", "a..b", "a.b[1].c"} {
+		ass.False(DeletePath(m, path), path)
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	m := decodeTestJSON(`{"a":{"b":[1,{"c":"x"},[]],"d":{}},"e":null,"f":"y"}`)
+
+	flat := Flatten(m)
+
+	ass.Equal(map[string]any{
+		"a.b[0]":   1.0,
+		"a.b[1].c": "x",
+		"a.b[2]":   []any{},
+		"a.d":      map[string]any{},
+		"e":        nil,
+		"f":        "y",
+	}, flat)
+
+	result, err := Unflatten(flat)
+	ass.Nil(err)
+	ass.Equal(m, result)
+
+	ass.Equal(map[string]any{}, Flatten(nil))
+}
+
+func TestUnflatten(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	result, err := Unflatten(map[string]any{"a.b": 1, "a.c[1]": 2})
+	ass.Nil(err)
+	ass.Equal(map[string]any{"a": map[string]any{"b": 1, "c": []any{nil, 2}}}, result)
+
+	_, err = Unflatten(map[string]any{"a.b": 1, "a": 2})
+	ass.Error(err)
+
+	_, err = Unflatten(map[string]any{"a.b": 1, "a[0]": 2})
+	ass.Error(err)
+
+	_, err = Unflatten(map[string]any{"a.": 1})
+	ass.Error(err)
+}
@@ -0,0 +1,152 @@
+package mapjez
+
+import (
+	"sync"
+)
+
+// SafeMap 并发安全的map。
+type SafeMap[K comparable, V any] struct {
+	m    map[K]V
+	lock *sync.RWMutex
+}
+
+// NewSafeMap 创建一个并发安全的map，m 为 nil 时会创建一个空map。
+func NewSafeMap[K comparable, V any](m map[K]V) *SafeMap[K, V] {
+	if m == nil {
+		m = make(map[K]V)
+	}
+
+	return &SafeMap[K, V]{
+		m:    m,
+		lock: new(sync.RWMutex),
+	}
+}
+
+// ForEach 遍历map，对每个元素调用 iteratee 函数。
+func (s *SafeMap[K, V]) ForEach(iteratee func(key K, value V)) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	ForEach(s.m, iteratee)
+}
+
+// Filter 遍历map，对每个元素调用 iteratee 函数，如果 iteratee 返回 true，则将该元素添加到结果map中。
+func (s *SafeMap[K, V]) Filter(iteratee func(key K, value V) bool) map[K]V {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return Filter(s.m, iteratee)
+}
+
+// Keys 返回map中所有的key。
+func (s *SafeMap[K, V]) Keys() []K {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return Keys(s.m)
+}
+
+// Values 返回map中所有的value。
+func (s *SafeMap[K, V]) Values() []V {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return Values(s.m)
+}
+
+// Get 返回 key 对应的value，如果不存在，ok 为 false。
+func (s *SafeMap[K, V]) Get(key K) (value V, ok bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	value, ok = s.m[key]
+	return
+}
+
+// Store 设置 key 对应的value。
+func (s *SafeMap[K, V]) Store(key K, value V) {
+	s.lock.Lock()
+	s.m[key] = value
+	s.lock.Unlock()
+}
+
+// LoadOrStore 如果 key 存在，则返回已有的value，loaded 为 true，否则存储并返回 value，loaded 为 false。
+func (s *SafeMap[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if actual, loaded = s.m[key]; loaded {
+		return
+	}
+
+	s.m[key] = value
+	return value, false
+}
+
+// GetOrCompute 如果 key 存在，则返回已有的value，否则调用 iteratee 函数计算value并存储，iteratee 在持有写锁时调用，最多调用一次。
+func (s *SafeMap[K, V]) GetOrCompute(key K, iteratee func() V) V {
+	// 先尝试读锁，避免 key 存在时获取写锁
+	if v, ok := s.Get(key); ok {
+		return v
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if v, ok := s.m[key]; ok {
+		return v
+	}
+
+	v := iteratee()
+	s.m[key] = v
+	return v
+}
+
+// Compute 原子地更新 key 对应的value。
+//
+// iteratee 接收当前的value及其是否存在，返回新的value及是否保留，如果返回 false，则删除该 key。
+// 返回最终的value及其是否存在，iteratee 在持有写锁时调用。
+func (s *SafeMap[K, V]) Compute(key K, iteratee func(value V, ok bool) (V, bool)) (V, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	old, ok := s.m[key]
+
+	v, keep := iteratee(old, ok)
+	if !keep {
+		delete(s.m, key)
+		var zero V
+		return zero, false
+	}
+
+	s.m[key] = v
+	return v, true
+}
+
+// Deletes 通过key删除多个元素。
+func (s *SafeMap[K, V]) Deletes(keys ...K) {
+	s.lock.Lock()
+	Deletes(s.m, keys...)
+	s.lock.Unlock()
+}
+
+// DeleteFilter 遍历map，对每个元素调用 iteratee 函数，如果 iteratee 返回true，则删除该元素。
+func (s *SafeMap[K, V]) DeleteFilter(iteratee func(key K, value V) bool) {
+	s.lock.Lock()
+	DeleteFilter(s.m, iteratee)
+	s.lock.Unlock()
+}
+
+// Load 返回map的副本。
+func (s *SafeMap[K, V]) Load() map[K]V {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	dst := make(map[K]V, len(s.m))
+	for k, v := range s.m {
+		dst[k] = v
+	}
+	return dst
+}
+
+// Len 返回map的长度。
+func (s *SafeMap[K, V]) Len() int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return len(s.m)
+}
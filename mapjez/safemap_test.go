@@ -0,0 +1,244 @@
+package mapjez
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func buildTestSafeMap(start, end int) *SafeMap[int, int] {
+	sm := NewSafeMap[int, int](nil)
+	for i := start; i < end; i++ {
+		sm.Store(i, i)
+	}
+
+	return sm
+}
+
+func TestNewSafeMap(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	ass.Equal(0, NewSafeMap[int, int](nil).Len())
+	ass.Equal(2, NewSafeMap(map[int]int{1: 1, 2: 2}).Len())
+}
+
+func TestSafeMap_ForEach(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+	sm := buildTestSafeMap(0, 1000)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sm.ForEach(func(key int, value int) {
+				ass.Equal(key, value)
+			})
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestSafeMap_Filter(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+	sm := buildTestSafeMap(0, 1000)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			actual := sm.Filter(func(key int, value int) bool {
+				return key < 500
+			})
+
+			ass.Equal(buildTestSafeMap(0, 500).Load(), actual)
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestSafeMap_KeysAndValues(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+	sm := buildTestSafeMap(0, 3)
+
+	keys := sm.Keys()
+	sort.Ints(keys)
+
+	values := sm.Values()
+	sort.Ints(values)
+
+	ass.Equal([]int{0, 1, 2}, keys)
+	ass.Equal([]int{0, 1, 2}, values)
+}
+
+func TestSafeMap_Store(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	sm := NewSafeMap[int, int](nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 1000; i++ {
+		wg.Add(1)
+		go func(v int) {
+			defer wg.Done()
+			sm.Store(v, v)
+		}(i)
+	}
+	wg.Wait()
+
+	ass.Equal(1000, sm.Len())
+
+	v, ok := sm.Get(999)
+	ass.True(ok)
+	ass.Equal(999, v)
+
+	_, ok = sm.Get(1000)
+	ass.False(ok)
+}
+
+func TestSafeMap_LoadOrStore(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	sm := NewSafeMap[string, int](nil)
+
+	var loaded int32
+
+	var wg sync.WaitGroup
+	for i := 0; i < 1000; i++ {
+		wg.Add(1)
+		go func(v int) {
+			defer wg.Done()
+			if _, ok := sm.LoadOrStore("a", v); ok {
+				atomic.AddInt32(&loaded, 1)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	ass.Equal(int32(999), loaded)
+
+	actual, ok := sm.LoadOrStore("a", -1)
+	ass.True(ok)
+	ass.NotEqual(-1, actual)
+}
+
+func TestSafeMap_GetOrCompute(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	sm := NewSafeMap[string, int](nil)
+
+	var calls int32
+
+	var wg sync.WaitGroup
+	for i := 0; i < 1000; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v := sm.GetOrCompute("a", func() int {
+				atomic.AddInt32(&calls, 1)
+				return 1
+			})
+			ass.Equal(1, v)
+		}()
+	}
+	wg.Wait()
+
+	ass.Equal(int32(1), calls)
+}
+
+func TestSafeMap_Compute(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	sm := NewSafeMap[string, int](nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 1000; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sm.Compute("a", func(value int, ok bool) (int, bool) {
+				return value + 1, true
+			})
+		}()
+	}
+	wg.Wait()
+
+	v, _ := sm.Get("a")
+	ass.Equal(1000, v)
+
+	// 返回 false 时删除
+	v, ok := sm.Compute("a", func(value int, ok bool) (int, bool) {
+		return 0, false
+	})
+
+	ass.False(ok)
+	ass.Equal(0, v)
+	ass.Equal(0, sm.Len())
+}
+
+func TestSafeMap_Deletes(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	sm := buildTestSafeMap(0, 1000)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 1000; i++ {
+		wg.Add(1)
+		go func(v int) {
+			defer wg.Done()
+			sm.Deletes(v)
+		}(i)
+	}
+	wg.Wait()
+
+	ass.Equal(0, sm.Len())
+}
+
+func TestSafeMap_DeleteFilter(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	sm := buildTestSafeMap(0, 1000)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sm.DeleteFilter(func(key int, value int) bool {
+				return key >= 500
+			})
+		}()
+	}
+	wg.Wait()
+
+	ass.Equal(buildTestSafeMap(0, 500).Load(), sm.Load())
+}
+
+func TestSafeMap_Load(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	sm := buildTestSafeMap(0, 10)
+
+	m := sm.Load()
+	m[100] = 100
+
+	ass.Equal(10, sm.Len())
+	ass.Len(m, 11)
+}
@@ -0,0 +1,331 @@
+package mapjez
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"sync"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Set 集合，基于 map[T]struct{} 实现，非并发安全，可以直接使用 make 创建。
+type Set[T comparable] map[T]struct{}
+
+// NewSet 创建一个集合，包含 items 中的元素。
+func NewSet[T comparable](items ...T) Set[T] {
+	s := make(Set[T], len(items))
+	s.Add(items...)
+	return s
+}
+
+// Add 添加多个元素。
+func (s Set[T]) Add(items ...T) {
+	for _, v := range items {
+		s[v] = struct{}{}
+	}
+}
+
+// Remove 删除多个元素。
+func (s Set[T]) Remove(items ...T) {
+	for _, v := range items {
+		delete(s, v)
+	}
+}
+
+// Has 判断元素是否存在。
+func (s Set[T]) Has(item T) bool {
+	_, ok := s[item]
+	return ok
+}
+
+// HasAll 判断所有元素是否都存在。
+func (s Set[T]) HasAll(items ...T) bool {
+	for _, v := range items {
+		if !s.Has(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// HasAny 判断是否有任意一个元素存在。
+func (s Set[T]) HasAny(items ...T) bool {
+	for _, v := range items {
+		if s.Has(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Len 返回元素数量。
+func (s Set[T]) Len() int {
+	return len(s)
+}
+
+// Clone 返回集合的副本。
+func (s Set[T]) Clone() Set[T] {
+	result := make(Set[T], len(s))
+	for v := range s {
+		result[v] = struct{}{}
+	}
+	return result
+}
+
+// ToSlice 返回所有元素，顺序不确定。
+func (s Set[T]) ToSlice() []T {
+	return Keys(s)
+}
+
+// ForEach 遍历集合，对每个元素调用 iteratee 函数，顺序不确定。
+func (s Set[T]) ForEach(iteratee func(item T)) {
+	for v := range s {
+		iteratee(v)
+	}
+}
+
+// Union 并集，返回新的集合，包含 s 和 others 中的所有元素。
+func (s Set[T]) Union(others ...Set[T]) Set[T] {
+	result := s.Clone()
+
+	for _, o := range others {
+		for v := range o {
+			result[v] = struct{}{}
+		}
+	}
+
+	return result
+}
+
+// Intersection 交集，返回新的集合，包含同时存在于 s 和 other 中的元素。
+func (s Set[T]) Intersection(other Set[T]) Set[T] {
+	small, large := s, other
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+
+	result := make(Set[T])
+
+	for v := range small {
+		if large.Has(v) {
+			result[v] = struct{}{}
+		}
+	}
+
+	return result
+}
+
+// Difference 差集，返回新的集合，包含存在于 s 但不存在于 other 中的元素。
+func (s Set[T]) Difference(other Set[T]) Set[T] {
+	result := make(Set[T])
+
+	for v := range s {
+		if !other.Has(v) {
+			result[v] = struct{}{}
+		}
+	}
+
+	return result
+}
+
+// SymmetricDifference 对称差集，返回新的集合，包含只存在于 s 或 other 其中一个的元素。
+func (s Set[T]) SymmetricDifference(other Set[T]) Set[T] {
+	result := s.Difference(other)
+
+	for v := range other {
+		if !s.Has(v) {
+			result[v] = struct{}{}
+		}
+	}
+
+	return result
+}
+
+// IsSubset 判断 s 是否为 other 的子集。
+func (s Set[T]) IsSubset(other Set[T]) bool {
+	if len(s) > len(other) {
+		return false
+	}
+
+	for v := range s {
+		if !other.Has(v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsSuperset 判断 s 是否为 other 的超集。
+func (s Set[T]) IsSuperset(other Set[T]) bool {
+	return other.IsSubset(s)
+}
+
+// Equal 判断两个集合的元素是否相同。
+func (s Set[T]) Equal(other Set[T]) bool {
+	return len(s) == len(other) && s.IsSubset(other)
+}
+
+// MarshalJSON 实现 json.Marshaler，输出为 JSON 数组，元素按编码后的结果排序，保证输出稳定。
+func (s Set[T]) MarshalJSON() ([]byte, error) {
+	items := make([][]byte, 0, len(s))
+
+	for v := range s {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, b)
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return bytes.Compare(items[i], items[j]) < 0
+	})
+
+	var buf bytes.Buffer
+
+	buf.WriteByte('[')
+	buf.Write(bytes.Join(items, []byte{','}))
+	buf.WriteByte(']')
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler，从 JSON 数组读取元素，原有元素会被清空。
+//
+// 按照 json.Unmarshaler 的约定，JSON 为 null 时不做任何修改，与 SafeSet、OrderedMap 相同。
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		return nil
+	}
+
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+
+	*s = NewSet(items...)
+
+	return nil
+}
+
+// 判断 JSON 是否为 null
+func isJSONNull(data []byte) bool {
+	return bytes.Equal(bytes.TrimSpace(data), []byte("null"))
+}
+
+// SetToSortedSlice 返回集合中的所有元素，按升序排列。
+func SetToSortedSlice[T constraints.Ordered](s Set[T]) []T {
+	return SortedKeys(s)
+}
+
+// SetToSortedSliceBy 返回集合中的所有元素，按 less 函数排序。
+func SetToSortedSliceBy[T comparable](s Set[T], less func(a, b T) bool) []T {
+	return SortedKeysBy(s, less)
+}
+
+// SafeSet 并发安全的集合。
+type SafeSet[T comparable] struct {
+	s    Set[T]
+	lock *sync.RWMutex
+}
+
+// NewSafeSet 创建一个并发安全的集合，包含 items 中的元素。
+func NewSafeSet[T comparable](items ...T) *SafeSet[T] {
+	return &SafeSet[T]{
+		s:    NewSet(items...),
+		lock: new(sync.RWMutex),
+	}
+}
+
+// Add 添加多个元素。
+func (s *SafeSet[T]) Add(items ...T) {
+	s.lock.Lock()
+	s.s.Add(items...)
+	s.lock.Unlock()
+}
+
+// AddIfNotExist 如果元素不存在则添加，返回是否添加成功。
+func (s *SafeSet[T]) AddIfNotExist(item T) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.s.Has(item) {
+		return false
+	}
+
+	s.s[item] = struct{}{}
+	return true
+}
+
+// Remove 删除多个元素。
+func (s *SafeSet[T]) Remove(items ...T) {
+	s.lock.Lock()
+	s.s.Remove(items...)
+	s.lock.Unlock()
+}
+
+// Has 判断元素是否存在。
+func (s *SafeSet[T]) Has(item T) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.s.Has(item)
+}
+
+// Len 返回元素数量。
+func (s *SafeSet[T]) Len() int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return len(s.s)
+}
+
+// ToSlice 返回所有元素，顺序不确定。
+func (s *SafeSet[T]) ToSlice() []T {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.s.ToSlice()
+}
+
+// ForEach 遍历集合，对每个元素调用 iteratee 函数，iteratee 中不能修改集合。
+func (s *SafeSet[T]) ForEach(iteratee func(item T)) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	s.s.ForEach(iteratee)
+}
+
+// Load 返回集合的副本，可以配合 Set 的集合运算使用。
+func (s *SafeSet[T]) Load() Set[T] {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.s.Clone()
+}
+
+// MarshalJSON 实现 json.Marshaler，与 Set 的输出相同。
+func (s *SafeSet[T]) MarshalJSON() ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.s.MarshalJSON()
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler，原有元素会被清空，JSON 为 null 时不做任何修改，与 Set 相同。
+func (s *SafeSet[T]) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		return nil
+	}
+
+	var set Set[T]
+	if err := set.UnmarshalJSON(data); err != nil {
+		return err
+	}
+
+	if s.lock == nil {
+		s.lock = new(sync.RWMutex)
+	}
+
+	s.lock.Lock()
+	s.s = set
+	s.lock.Unlock()
+
+	return nil
+}
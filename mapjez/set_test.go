@@ -0,0 +1,202 @@
+package mapjez
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSet(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	s := NewSet(1, 2, 2, 3)
+
+	ass.Equal(3, s.Len())
+	ass.True(s.Has(1))
+	ass.False(s.Has(4))
+	ass.True(s.HasAll(1, 3))
+	ass.False(s.HasAll(1, 4))
+	ass.True(s.HasAny(4, 3))
+	ass.False(s.HasAny(4, 5))
+	ass.True(s.HasAll())
+	ass.False(s.HasAny())
+
+	s.Add(4, 5)
+	s.Remove(1, 6)
+
+	ass.ElementsMatch([]int{2, 3, 4, 5}, s.ToSlice())
+
+	c := s.Clone()
+	c.Add(6)
+	ass.False(s.Has(6))
+
+	sum := 0
+	s.ForEach(func(item int) {
+		sum += item
+	})
+	ass.Equal(14, sum)
+
+	// 可以直接使用 make 创建
+	m := make(Set[string])
+	m.Add("a")
+	ass.Equal(Set[string]{"a": {}}, m)
+}
+
+func TestSet_Algebra(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	a := NewSet(1, 2, 3)
+	b := NewSet(2, 3, 4)
+
+	ass.Equal(NewSet(1, 2, 3, 4, 5), a.Union(b, NewSet(5)))
+	ass.Equal(NewSet(1, 2, 3), a.Union())
+	ass.Equal(NewSet(2, 3), a.Intersection(b))
+	ass.Equal(NewSet(2, 3), b.Intersection(a))
+	ass.Equal(NewSet(1), a.Difference(b))
+	ass.Equal(NewSet(1, 4), a.SymmetricDifference(b))
+
+	ass.Equal(NewSet[int](), a.Intersection(nil))
+	ass.Equal(a, a.Difference(nil))
+
+	// 参数不会被修改
+	ass.Equal(NewSet(1, 2, 3), a)
+
+	ass.True(NewSet(2, 3).IsSubset(a))
+	ass.True(a.IsSubset(a))
+	ass.False(a.IsSubset(b))
+	ass.False(a.IsSubset(NewSet(1)))
+	ass.True(Set[int](nil).IsSubset(a))
+
+	ass.True(a.IsSuperset(NewSet(1, 2)))
+	ass.False(a.IsSuperset(b))
+
+	ass.True(a.Equal(NewSet(3, 2, 1)))
+	ass.False(a.Equal(b))
+	ass.False(a.Equal(NewSet(1, 2)))
+	ass.True(NewSet[int]().Equal(nil))
+}
+
+func TestSetToSortedSlice(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	s := NewSet(3, 1, 2)
+
+	ass.Equal([]int{1, 2, 3}, SetToSortedSlice(s))
+	ass.Equal([]int{3, 2, 1}, SetToSortedSliceBy(s, func(a, b int) bool {
+		return a > b
+	}))
+}
+
+func TestSet_JSON(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	b, err := json.Marshal(NewSet("b", "c", "a"))
+	ass.Nil(err)
+	ass.Equal(`["a","b","c"]`, string(b))
+
+	b, err = json.Marshal(NewSet[int]())
+	ass.Nil(err)
+	ass.Equal(`[]`, string(b))
+
+	type config struct {
+		Tags Set[string] `json:"tags"`
+	}
+
+	var c config
+	ass.Nil(json.Unmarshal([]byte(`{"tags":["x","y","x"]}`), &c))
+	ass.Equal(NewSet("x", "y"), c.Tags)
+
+	// null 不做任何修改
+	ass.Nil(json.Unmarshal([]byte(`{"tags":null}`), &c))
+	ass.Equal(NewSet("x", "y"), c.Tags)
+
+	var s Set[string]
+	ass.Nil(json.Unmarshal([]byte(`null`), &s))
+	ass.Nil(s)
+
+	ass.Error(json.Unmarshal([]byte(`{"tags":{}}`), &c))
+
+	_, err = json.Marshal(NewSet(make(chan int)))
+	ass.Error(err)
+}
+
+func TestSafeSet(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	s := NewSafeSet[int]()
+
+	var wg sync.WaitGroup
+
+	added := make(chan int, 100)
+
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(v int) {
+			defer wg.Done()
+			s.Add(v)
+			s.Has(v)
+			if s.AddIfNotExist(v % 10) {
+				added <- v % 10
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	close(added)
+
+	// 每个值只会被 AddIfNotExist 添加一次
+	var list []int
+	for v := range added {
+		list = append(list, v)
+	}
+	ass.Len(NewSet(list...), len(list))
+
+	ass.Equal(100, s.Len())
+
+	s.Remove(0, 1)
+
+	ass.Equal(98, s.Len())
+	ass.False(s.Has(0))
+	ass.Len(s.ToSlice(), 98)
+
+	sum := 0
+	s.ForEach(func(item int) {
+		sum += item
+	})
+	ass.Equal(4949, sum)
+
+	// Load 返回副本
+	loaded := s.Load()
+	loaded.Add(1000)
+	ass.False(s.Has(1000))
+}
+
+func TestSafeSet_JSON(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	b, err := json.Marshal(NewSafeSet(2, 1))
+	ass.Nil(err)
+	ass.Equal(`[1,2]`, string(b))
+
+	type config struct {
+		IDs SafeSet[int] `json:"ids"`
+	}
+
+	var c config
+	ass.Nil(json.Unmarshal([]byte(`{"ids":[3,1]}`), &c))
+	ass.True(c.IDs.Has(3))
+	ass.Equal(2, c.IDs.Len())
+
+	ass.Nil(json.Unmarshal([]byte(`{"ids":null}`), &c))
+	ass.Equal(2, c.IDs.Len())
+
+	ass.Error(json.Unmarshal([]byte(`{"ids":["a"]}`), &c))
+}
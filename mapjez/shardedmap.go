@@ -0,0 +1,251 @@
+package mapjez
+
+import (
+	"hash/maphash"
+	"math"
+	"reflect"
+)
+
+// DefaultShardCount ShardedMap 默认的分片数量。
+const DefaultShardCount = 32
+
+// 所有 ShardedMap 共用的哈希种子，保证同一进程内同一 key 的哈希值稳定
+var hashSeed = maphash.MakeSeed()
+
+// ShardedMap 分片的并发安全map，每个分片使用独立的读写锁，适用于高并发的场景。
+type ShardedMap[K comparable, V any] struct {
+	shards []*SafeMap[K, V]
+	hasher func(key K) uint64
+}
+
+// NewShardedMap 创建一个分片的并发安全map。
+//
+// 参数：
+//   - shardCount: 分片数量，<= 0 时使用 DefaultShardCount
+//   - hasher: 可选参数，计算 key 的哈希值，为 nil 时使用 DefaultHasher
+func NewShardedMap[K comparable, V any](shardCount int, hasher func(key K) uint64) *ShardedMap[K, V] {
+	if shardCount <= 0 {
+		shardCount = DefaultShardCount
+	}
+
+	if hasher == nil {
+		hasher = DefaultHasher[K]
+	}
+
+	shards := make([]*SafeMap[K, V], shardCount)
+	for i := range shards {
+		shards[i] = NewSafeMap[K, V](nil)
+	}
+
+	return &ShardedMap[K, V]{
+		shards: shards,
+		hasher: hasher,
+	}
+}
+
+// DefaultHasher 默认的哈希函数，对字符串、数字和布尔类型直接计算哈希值，
+// 指针和通道使用其地址计算哈希值，结构体、数组和接口按其包含的值逐个计算哈希值。
+//
+// 哈希值与 == 的语义一致，不会读取指针指向的内容，修改指向的内容不会影响哈希值。
+// 对于结构体等复杂类型的 key，建议传入自定义的哈希函数以获得更好的性能。
+func DefaultHasher[K comparable](key K) uint64 {
+	switch k := any(key).(type) {
+	case string:
+		return maphash.String(hashSeed, k)
+	case int:
+		return mix(uint64(k))
+	case int64:
+		return mix(uint64(k))
+	case uint64:
+		return mix(k)
+	case float64:
+		return hashFloat(k)
+	}
+
+	return hashValue(reflect.ValueOf(&key).Elem())
+}
+
+// 按值的类型计算哈希值，与 == 的比较方式保持一致
+func hashValue(v reflect.Value) uint64 {
+	switch v.Kind() {
+	case reflect.String:
+		return maphash.String(hashSeed, v.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return mix(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return mix(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return hashFloat(v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		return combineHash(hashFloat(real(c)), hashFloat(imag(c)))
+	case reflect.Bool:
+		if v.Bool() {
+			return 1
+		}
+		return 0
+	case reflect.Pointer, reflect.UnsafePointer, reflect.Chan:
+		return mix(uint64(v.Pointer()))
+	case reflect.Interface:
+		if v.IsNil() {
+			return 0
+		}
+		return hashValue(v.Elem())
+	case reflect.Struct:
+		var h uint64
+		for i := 0; i < v.NumField(); i++ {
+			h = combineHash(h, hashValue(v.Field(i)))
+		}
+		return h
+	case reflect.Array:
+		var h uint64
+		for i := 0; i < v.Len(); i++ {
+			h = combineHash(h, hashValue(v.Index(i)))
+		}
+		return h
+	}
+
+	// 接口中保存了不可比较的值，与使用其作为 map 的 key 时一致，直接 panic
+	panic("mapjez: unhashable type " + v.Type().String())
+}
+
+// 计算浮点数的哈希值，-0 和 +0 相等，哈希值也相同
+func hashFloat(f float64) uint64 {
+	if f == 0 {
+		f = 0
+	}
+	return mix(math.Float64bits(f))
+}
+
+// 组合两个哈希值，结果与顺序有关
+func combineHash(h, v uint64) uint64 {
+	return mix(h ^ (v + 0x9e3779b97f4a7c15 + h<<6 + h>>2))
+}
+
+// 打散整数的哈希值，避免连续的整数落在相邻的分片上（splitmix64）
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// 返回 key 所在的分片
+func (s *ShardedMap[K, V]) shard(key K) *SafeMap[K, V] {
+	return s.shards[s.hasher(key)%uint64(len(s.shards))]
+}
+
+// ShardCount 返回分片数量。
+func (s *ShardedMap[K, V]) ShardCount() int {
+	return len(s.shards)
+}
+
+// Get 返回 key 对应的value，如果不存在，ok 为 false。
+func (s *ShardedMap[K, V]) Get(key K) (V, bool) {
+	return s.shard(key).Get(key)
+}
+
+// Store 设置 key 对应的value。
+func (s *ShardedMap[K, V]) Store(key K, value V) {
+	s.shard(key).Store(key, value)
+}
+
+// StoreMap 批量设置元素，涉及的分片按顺序同时加锁，写入对 Load 等快照操作是原子的。
+func (s *ShardedMap[K, V]) StoreMap(m map[K]V) {
+	groups := make([]map[K]V, len(s.shards))
+
+	for k, v := range m {
+		i := s.hasher(k) % uint64(len(s.shards))
+		if groups[i] == nil {
+			groups[i] = make(map[K]V)
+		}
+		groups[i][k] = v
+	}
+
+	// 按分片顺序加锁，与 Load 的加锁顺序一致，避免死锁
+	for i, group := range groups {
+		if group != nil {
+			s.shards[i].lock.Lock()
+		}
+	}
+
+	for i, group := range groups {
+		if group == nil {
+			continue
+		}
+
+		shard := s.shards[i]
+		for k, v := range group {
+			shard.m[k] = v
+		}
+		shard.lock.Unlock()
+	}
+}
+
+// LoadOrStore 如果 key 存在，则返回已有的value，loaded 为 true，否则存储并返回 value，loaded 为 false。
+func (s *ShardedMap[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	return s.shard(key).LoadOrStore(key, value)
+}
+
+// Compute 原子地更新 key 对应的value，iteratee 返回 false 时删除该 key，只锁定 key 所在的分片。
+func (s *ShardedMap[K, V]) Compute(key K, iteratee func(value V, ok bool) (V, bool)) (V, bool) {
+	return s.shard(key).Compute(key, iteratee)
+}
+
+// Deletes 通过key删除多个元素。
+func (s *ShardedMap[K, V]) Deletes(keys ...K) {
+	for _, k := range keys {
+		s.shard(k).Deletes(k)
+	}
+}
+
+// Len 返回map的长度，各分片分别加锁统计，并发写入时结果只是近似值。
+func (s *ShardedMap[K, V]) Len() int {
+	var n int
+	for _, shard := range s.shards {
+		n += shard.Len()
+	}
+	return n
+}
+
+// Load 返回map的一致性快照，快照期间会同时锁定所有分片。
+func (s *ShardedMap[K, V]) Load() map[K]V {
+	for _, shard := range s.shards {
+		shard.lock.RLock()
+	}
+
+	var n int
+	for _, shard := range s.shards {
+		n += len(shard.m)
+	}
+
+	dst := make(map[K]V, n)
+	for _, shard := range s.shards {
+		for k, v := range shard.m {
+			dst[k] = v
+		}
+	}
+
+	for _, shard := range s.shards {
+		shard.lock.RUnlock()
+	}
+
+	return dst
+}
+
+// ForEach 遍历map的一致性快照，对每个元素调用 iteratee 函数，调用 iteratee 时不持有锁。
+func (s *ShardedMap[K, V]) ForEach(iteratee func(key K, value V)) {
+	ForEach(s.Load(), iteratee)
+}
+
+// Keys 返回map快照中所有的key。
+func (s *ShardedMap[K, V]) Keys() []K {
+	return Keys(s.Load())
+}
+
+// Values 返回map快照中所有的value。
+func (s *ShardedMap[K, V]) Values() []V {
+	return Values(s.Load())
+}
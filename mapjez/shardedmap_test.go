@@ -0,0 +1,242 @@
+package mapjez
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewShardedMap(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	ass.Equal(DefaultShardCount, NewShardedMap[int, int](0, nil).ShardCount())
+	ass.Equal(8, NewShardedMap[int, int](8, nil).ShardCount())
+
+	// 自定义哈希函数，所有 key 落在同一个分片
+	sm := NewShardedMap[int, int](4, func(key int) uint64 {
+		return 1
+	})
+
+	sm.StoreMap(map[int]int{1: 1, 2: 2, 3: 3})
+
+	ass.Equal(3, sm.shards[1].Len())
+	ass.Equal(3, sm.Len())
+}
+
+func TestDefaultHasher(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	type key struct {
+		a int
+		b string
+	}
+
+	ass.Equal(DefaultHasher("a"), DefaultHasher("a"))
+	ass.NotEqual(DefaultHasher("a"), DefaultHasher("b"))
+	ass.Equal(DefaultHasher(1), DefaultHasher(1))
+	ass.NotEqual(DefaultHasher(1), DefaultHasher(2))
+	ass.NotEqual(DefaultHasher(1.5), DefaultHasher(2.5))
+	ass.NotEqual(DefaultHasher(true), DefaultHasher(false))
+	ass.Equal(DefaultHasher(key{1, "a"}), DefaultHasher(key{1, "a"}))
+	ass.NotEqual(DefaultHasher(key{1, "a"}), DefaultHasher(key{2, "a"}))
+	ass.Equal(DefaultHasher(0.0), DefaultHasher(math.Copysign(0, -1)))
+	ass.Equal(DefaultHasher(float32(0)), DefaultHasher(float32(math.Copysign(0, -1))))
+	ass.Equal(DefaultHasher([2]string{"a", "b"}), DefaultHasher([2]string{"a", "b"}))
+	ass.NotEqual(DefaultHasher([2]string{"a", "b"}), DefaultHasher([2]string{"b", "a"}))
+
+	// 指针使用地址计算哈希值，修改指向的内容不影响哈希值
+	p := &key{1, "a"}
+	h := DefaultHasher(p)
+	p.a = 2
+	ass.Equal(h, DefaultHasher(p))
+}
+
+func TestShardedMap_PointerKey(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	type node struct {
+		n int
+	}
+
+	sm := NewShardedMap[*node, int](0, nil)
+
+	nodes := make([]*node, 100)
+	for i := range nodes {
+		nodes[i] = &node{n: i}
+		sm.Store(nodes[i], i)
+	}
+
+	for _, n := range nodes {
+		n.n += 1000
+	}
+
+	for i, n := range nodes {
+		v, ok := sm.Get(n)
+		ass.True(ok)
+		ass.Equal(i, v)
+	}
+}
+
+func TestShardedMap_NegativeZero(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	sm := NewShardedMap[float64, int](0, nil)
+	sm.Store(0.0, 1)
+	sm.Store(math.Copysign(0, -1), 2)
+
+	ass.Equal(1, sm.Len())
+
+	v, ok := sm.Get(0)
+	ass.True(ok)
+	ass.Equal(2, v)
+}
+
+func TestShardedMap(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	sm := NewShardedMap[int, int](16, nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 1000; i++ {
+		wg.Add(1)
+		go func(v int) {
+			defer wg.Done()
+			sm.Store(v, v)
+			sm.Compute(-1, func(value int, ok bool) (int, bool) {
+				return value + 1, true
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	ass.Equal(1001, sm.Len())
+
+	v, ok := sm.Get(-1)
+	ass.True(ok)
+	ass.Equal(1000, v)
+
+	actual, loaded := sm.LoadOrStore(1, 100)
+	ass.True(loaded)
+	ass.Equal(1, actual)
+
+	sm.Deletes(-1, 0)
+
+	_, ok = sm.Get(0)
+	ass.False(ok)
+
+	keys := sm.Keys()
+	sort.Ints(keys)
+
+	values := sm.Values()
+	sort.Ints(values)
+
+	ass.Len(keys, 999)
+	ass.Equal(1, keys[0])
+	ass.Equal(keys, values)
+
+	var sum int
+	sm.ForEach(func(key int, value int) {
+		sum += value
+	})
+	ass.Equal(999*1000/2, sum)
+}
+
+func TestShardedMap_StoreMap(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	m := make(map[string]int)
+	for i := 0; i < 100; i++ {
+		m[strconv.Itoa(i)] = i
+	}
+
+	sm := NewShardedMap[string, int](0, nil)
+	sm.StoreMap(m)
+
+	ass.Equal(m, sm.Load())
+}
+
+func TestShardedMap_Load(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	sm := NewShardedMap[int, int](4, nil)
+
+	// 两个 key 总是一起修改，快照中二者必须一致
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(2)
+		go func(v int) {
+			defer wg.Done()
+			sm.StoreMap(map[int]int{1: v, 2: v})
+		}(i)
+		go func() {
+			defer wg.Done()
+			m := sm.Load()
+			ass.Equal(m[1], m[2])
+		}()
+	}
+	wg.Wait()
+}
+
+const benchKeys = 1 << 10
+
+func BenchmarkShardedMap(b *testing.B) {
+	sm := NewShardedMap[int, int](0, nil)
+
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			k := i % benchKeys
+			if i%4 == 0 {
+				sm.Store(k, i)
+			} else {
+				sm.Get(k)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkSafeMap(b *testing.B) {
+	sm := NewSafeMap[int, int](nil)
+
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			k := i % benchKeys
+			if i%4 == 0 {
+				sm.Store(k, i)
+			} else {
+				sm.Get(k)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkSyncMap(b *testing.B) {
+	var sm sync.Map
+
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			k := i % benchKeys
+			if i%4 == 0 {
+				sm.Store(k, i)
+			} else {
+				sm.Load(k)
+			}
+			i++
+		}
+	})
+}
@@ -0,0 +1,571 @@
+package mapjez
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/dengrandpa/jez/internal/multierr"
+)
+
+// StructOptions StructToMapWithOptions 和 MapToStructWithOptions 的配置。
+type StructOptions struct {
+	TagName string // 读取字段名的 tag，默认为 "json"
+}
+
+func (o StructOptions) tagName() string {
+	if o.TagName == "" {
+		return "json"
+	}
+	return o.TagName
+}
+
+// FieldError MapToStruct 中单个字段转换失败的错误。
+type FieldError struct {
+	Path string // 字段在map中的路径，格式与 GetPath 相同，如 "items[1].price"
+	Err  error
+}
+
+// Error 返回字段路径及原因
+func (e *FieldError) Error() string {
+	return e.Path + ": " + e.Err.Error()
+}
+
+// Unwrap 返回原始错误
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// StructError MapToStruct 的聚合错误，记录每个转换失败的字段及其原因，其余字段已正常写入
+//
+// errors.Is、errors.As 会检查每个字段的错误，如 errors.Is(err, strconv.ErrSyntax) 可以判断是否有字符串解析失败的字段。
+type StructError struct {
+	Errors []*FieldError
+}
+
+// Error 返回失败字段的数量，之后每行一个失败字段的路径及原因
+func (e *StructError) Error() string {
+	return multierr.Format("mapjez", "field error(s)", e.Errors)
+}
+
+// Paths 返回所有失败字段的路径
+func (e *StructError) Paths() []string {
+	paths := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		paths = append(paths, err.Path)
+	}
+	return paths
+}
+
+// Is 判断是否有任意一个字段的错误匹配 target
+func (e *StructError) Is(target error) bool {
+	return multierr.Is(e.Errors, target)
+}
+
+// As 查找第一个可以赋值给 target 的字段错误
+func (e *StructError) As(target any) bool {
+	return multierr.As(e.Errors, target)
+}
+
+// 结构体中的一个字段，index 为 reflect.Value.FieldByIndex 的参数
+type structField struct {
+	name      string
+	index     []int
+	omitEmpty bool
+}
+
+// 返回结构体的所有字段，匿名嵌入且没有指定名称的结构体字段会被展开，外层的同名字段优先
+func structFields(t reflect.Type, tag string) []structField {
+	return collectStructFields(t, tag, make(map[reflect.Type]bool))
+}
+
+// visited 记录当前嵌入路径上的结构体类型，循环嵌入（如 type Node struct{ *Node }）时不再展开
+func collectStructFields(t reflect.Type, tag string, visited map[reflect.Type]bool) []structField {
+	visited[t] = true
+	defer delete(visited, t)
+
+	var fields, embedded []structField
+
+	names := make(map[string]bool)
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		tagValue := f.Tag.Get(tag)
+		if tagValue == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tagValue, ",")
+
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+
+			if ft.Kind() == reflect.Struct {
+				if visited[ft] {
+					continue
+				}
+
+				for _, ef := range collectStructFields(ft, tag, visited) {
+					ef.index = append([]int{i}, ef.index...)
+					embedded = append(embedded, ef)
+				}
+				continue
+			}
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+
+		fields = append(fields, structField{
+			name:      name,
+			index:     []int{i},
+			omitEmpty: strings.Contains(","+opts+",", ",omitempty,"),
+		})
+		names[name] = true
+	}
+
+	for _, ef := range embedded {
+		if !names[ef.name] {
+			names[ef.name] = true
+			fields = append(fields, ef)
+		}
+	}
+
+	return fields
+}
+
+// 与 encoding/json 的 omitempty 规则一致
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Pointer:
+		return v.IsNil()
+	}
+	return false
+}
+
+var (
+	jsonMarshalerType   = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// 实现了 json.Marshaler 或 encoding.TextMarshaler 的类型（如 time.Time）保持原值，不转换为map
+func isMarshaler(t reflect.Type) bool {
+	return t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType)
+}
+
+// 类型中是否包含需要转换为map的结构体
+func needsConvert(t reflect.Type) bool {
+	if isMarshaler(t) {
+		return false
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		return true
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		return needsConvert(t.Elem())
+	}
+
+	return false
+}
+
+// StructToMap 将结构体或结构体指针转换为 map[string]any，使用 json tag 作为key，与 StructToMapWithOptions 相同。
+func StructToMap(v any) (map[string]any, error) {
+	return StructToMapWithOptions(v, StructOptions{})
+}
+
+// StructToMapWithOptions 将结构体或结构体指针转换为 map[string]any。
+//
+// key 为 tag 中的名称，没有 tag 时为字段名，tag 为 "-" 时忽略该字段，支持 omitempty。
+// 匿名嵌入的结构体字段会被展开到外层，嵌套的结构体及结构体切片、map 会递归转换，
+// 实现了 json.Marshaler 或 encoding.TextMarshaler 的类型（如 time.Time）保持原值。
+func StructToMapWithOptions(v any, opts StructOptions) (map[string]any, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("mapjez: StructToMap requires a struct or a non-nil pointer to struct, got %T", v)
+	}
+
+	return structToMap(rv, opts.tagName()), nil
+}
+
+func structToMap(v reflect.Value, tag string) map[string]any {
+	fields := structFields(v.Type(), tag)
+	result := make(map[string]any, len(fields))
+
+	for _, f := range fields {
+		fv, ok := fieldByIndex(v, f.index)
+		if !ok || (f.omitEmpty && isEmptyValue(fv)) {
+			continue
+		}
+
+		result[f.name] = toMapValue(fv, tag)
+	}
+
+	return result
+}
+
+// 嵌入的结构体指针为 nil 时，ok 为 false
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+func toMapValue(v reflect.Value, tag string) any {
+	if !needsConvert(v.Type()) {
+		return v.Interface()
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return nil
+		}
+		return toMapValue(v.Elem(), tag)
+
+	case reflect.Struct:
+		return structToMap(v, tag)
+
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return []any(nil)
+		}
+
+		result := make([]any, v.Len())
+		for i := range result {
+			result[i] = toMapValue(v.Index(i), tag)
+		}
+		return result
+
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return v.Interface()
+		}
+
+		if v.IsNil() {
+			return map[string]any(nil)
+		}
+
+		result := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			result[iter.Key().String()] = toMapValue(iter.Value(), tag)
+		}
+		return result
+	}
+
+	return v.Interface()
+}
+
+// MapToStruct 将 map[string]any 转换为结构体，使用 json tag 作为key，与 MapToStructWithOptions 相同。
+func MapToStruct(m map[string]any, dst any) error {
+	return MapToStructWithOptions(m, dst, StructOptions{})
+}
+
+// MapToStructWithOptions 将 map[string]any 写入 dst 指向的结构体，字段规则与 StructToMapWithOptions 相同，map 中不存在的字段保持不变，多余的key被忽略。
+//
+// 数值类型之间在不溢出时可以相互转换，浮点数只有为整数值时才能转换为整数，转换为浮点数时可能丢失精度（如 float64 转换为 float32），
+// 字符串可以转换为数值和布尔值，数值和布尔值也可以转换为字符串，
+// 实现了 encoding.TextUnmarshaler 的类型（如 time.Time）可以从字符串转换。
+//
+// 转换失败的字段会被跳过，其余字段正常写入，并返回 *StructError，包含每个失败字段的路径及原因。
+func MapToStructWithOptions(m map[string]any, dst any, opts StructOptions) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("mapjez: MapToStruct requires a non-nil pointer to struct, got %T", dst)
+	}
+
+	d := &structDecoder{tag: opts.tagName()}
+	d.decodeStruct(m, rv.Elem(), "")
+
+	if len(d.errs) > 0 {
+		return &StructError{Errors: d.errs}
+	}
+
+	return nil
+}
+
+type structDecoder struct {
+	tag  string
+	errs []*FieldError
+}
+
+func (d *structDecoder) fail(path string, err error) {
+	d.errs = append(d.errs, &FieldError{Path: path, Err: err})
+}
+
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+func (d *structDecoder) decodeStruct(m map[string]any, dst reflect.Value, path string) {
+	for _, f := range structFields(dst.Type(), d.tag) {
+		src, ok := m[f.name]
+		if !ok {
+			continue
+		}
+
+		fieldPath := joinKey(path, f.name)
+
+		fv, err := fieldByIndexAlloc(dst, f.index)
+		if err != nil {
+			d.fail(fieldPath, err)
+			continue
+		}
+
+		d.decode(src, fv, fieldPath)
+	}
+}
+
+// 嵌入的结构体指针为 nil 时自动创建
+func fieldByIndexAlloc(v reflect.Value, index []int) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, fmt.Errorf("cannot set embedded pointer to unexported struct %s", v.Type().Elem())
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, nil
+}
+
+func (d *structDecoder) decode(src any, dst reflect.Value, path string) {
+	if src == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return
+	}
+
+	sv := reflect.ValueOf(src)
+
+	if sv.Type().AssignableTo(dst.Type()) {
+		dst.Set(sv)
+		return
+	}
+
+	if dst.Kind() == reflect.Pointer {
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		d.decode(src, dst.Elem(), path)
+		return
+	}
+
+	if s, ok := src.(string); ok && reflect.PointerTo(dst.Type()).Implements(textUnmarshalerType) {
+		if err := dst.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s)); err != nil {
+			d.fail(path, err)
+		}
+		return
+	}
+
+	if err := d.decodeKind(src, sv, dst, path); err != nil {
+		d.fail(path, err)
+	}
+}
+
+func (d *structDecoder) decodeKind(src any, sv, dst reflect.Value, path string) error {
+	switch dst.Kind() {
+	case reflect.Struct:
+		m, ok := src.(map[string]any)
+		if !ok {
+			return convertError(src, dst)
+		}
+		d.decodeStruct(m, dst, path)
+
+	case reflect.Map:
+		if dst.Type().Key().Kind() != reflect.String || sv.Kind() != reflect.Map || sv.Type().Key().Kind() != reflect.String {
+			return convertError(src, dst)
+		}
+
+		result := reflect.MakeMapWithSize(dst.Type(), sv.Len())
+		iter := sv.MapRange()
+		for iter.Next() {
+			key := iter.Key().String()
+			elem := reflect.New(dst.Type().Elem()).Elem()
+			d.decode(iter.Value().Interface(), elem, joinKey(path, key))
+			result.SetMapIndex(reflect.ValueOf(key).Convert(dst.Type().Key()), elem)
+		}
+		dst.Set(result)
+
+	case reflect.Slice, reflect.Array:
+		if sv.Kind() != reflect.Slice && sv.Kind() != reflect.Array {
+			return convertError(src, dst)
+		}
+
+		result := dst
+		if dst.Kind() == reflect.Slice {
+			result = reflect.MakeSlice(dst.Type(), sv.Len(), sv.Len())
+		} else if sv.Len() > dst.Len() {
+			return fmt.Errorf("cannot convert %d elements to %s", sv.Len(), dst.Type())
+		}
+
+		for i := 0; i < sv.Len(); i++ {
+			d.decode(sv.Index(i).Interface(), result.Index(i), path+"["+strconv.Itoa(i)+"]")
+		}
+
+		if dst.Kind() == reflect.Slice {
+			dst.Set(result)
+		}
+
+	case reflect.Bool:
+		switch sv.Kind() {
+		case reflect.Bool:
+			dst.SetBool(sv.Bool())
+		case reflect.String:
+			b, err := strconv.ParseBool(sv.String())
+			if err != nil {
+				return err
+			}
+			dst.SetBool(b)
+		default:
+			return convertError(src, dst)
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := coerceInt(src, sv)
+		if err != nil {
+			return err
+		}
+		if dst.OverflowInt(n) {
+			return fmt.Errorf("value %v overflows %s", src, dst.Type())
+		}
+		dst.SetInt(n)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := coerceUint(src, sv)
+		if err != nil {
+			return err
+		}
+		if dst.OverflowUint(n) {
+			return fmt.Errorf("value %v overflows %s", src, dst.Type())
+		}
+		dst.SetUint(n)
+
+	case reflect.Float32, reflect.Float64:
+		f, ok := toFloat64(src)
+		if !ok {
+			if sv.Kind() != reflect.String {
+				return convertError(src, dst)
+			}
+
+			var err error
+			if f, err = strconv.ParseFloat(sv.String(), 64); err != nil {
+				return err
+			}
+		}
+		if dst.OverflowFloat(f) {
+			return fmt.Errorf("value %v overflows %s", src, dst.Type())
+		}
+		dst.SetFloat(f)
+
+	case reflect.String:
+		switch sv.Kind() {
+		case reflect.String:
+			dst.SetString(sv.String())
+		case reflect.Bool:
+			dst.SetString(strconv.FormatBool(sv.Bool()))
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			dst.SetString(strconv.FormatInt(sv.Int(), 10))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			dst.SetString(strconv.FormatUint(sv.Uint(), 10))
+		case reflect.Float32, reflect.Float64:
+			dst.SetString(strconv.FormatFloat(sv.Float(), 'f', -1, sv.Type().Bits()))
+		default:
+			return convertError(src, dst)
+		}
+
+	default:
+		return convertError(src, dst)
+	}
+
+	return nil
+}
+
+func convertError(src any, dst reflect.Value) error {
+	return fmt.Errorf("cannot convert %T to %s", src, dst.Type())
+}
+
+func coerceInt(src any, sv reflect.Value) (int64, error) {
+	if n, ok := toInt64(src); ok {
+		return n, nil
+	}
+
+	switch sv.Kind() {
+	case reflect.String:
+		return strconv.ParseInt(sv.String(), 10, 64)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return 0, fmt.Errorf("value %v overflows int64", src)
+	case reflect.Float32, reflect.Float64:
+		return 0, fmt.Errorf("value %v is not an integer", src)
+	}
+
+	return 0, fmt.Errorf("cannot convert %T to integer", src)
+}
+
+func coerceUint(src any, sv reflect.Value) (uint64, error) {
+	switch sv.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return sv.Uint(), nil
+	case reflect.Float32, reflect.Float64:
+		f := sv.Float()
+		if f != math.Trunc(f) || f < 0 || f >= math.MaxUint64 {
+			return 0, fmt.Errorf("value %v is not an unsigned integer", src)
+		}
+		return uint64(f), nil
+	}
+
+	if n, ok := toInt64(src); ok {
+		if n < 0 {
+			return 0, fmt.Errorf("value %v is negative", src)
+		}
+		return uint64(n), nil
+	}
+
+	if sv.Kind() == reflect.String {
+		return strconv.ParseUint(sv.String(), 10, 64)
+	}
+
+	return 0, fmt.Errorf("cannot convert %T to unsigned integer", src)
+}
@@ -0,0 +1,290 @@
+package mapjez
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testBase struct {
+	ID      int       `json:"id"`
+	Created time.Time `json:"created"`
+}
+
+// 嵌入的结构体指针需要导出，否则 MapToStruct 无法创建
+type TestMeta struct {
+	Source string `json:"source"`
+	ID     string `json:"id"` // 被外层的 id 覆盖
+}
+
+type testItem struct {
+	Name  string  `json:"name"`
+	Price float64 `json:"price"`
+}
+
+type testOrder struct {
+	testBase
+	*TestMeta
+	Customer string              `json:"customer"`
+	Note     string              `json:"note,omitempty"`
+	Items    []testItem          `json:"items"`
+	Extra    map[string]testItem `json:"extra,omitempty"`
+	Ship     *testItem           `json:"ship"`
+	Tags     []string            `json:"tags,omitempty"`
+	Secret   string              `json:"-"`
+	Plain    bool
+	internal int
+}
+
+func TestStructToMap(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	created := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	o := testOrder{
+		testBase: testBase{ID: 1, Created: created},
+		TestMeta: &TestMeta{Source: "web", ID: "meta"},
+		Customer: "bob",
+		Items:    []testItem{{Name: "a", Price: 1.5}},
+		Extra:    map[string]testItem{"gift": {Name: "b"}},
+		Secret:   "x",
+		internal: 1,
+	}
+
+	m, err := StructToMap(&o)
+	ass.Nil(err)
+
+	ass.Equal(map[string]any{
+		"id":       1,
+		"created":  created,
+		"source":   "web",
+		"customer": "bob",
+		"items":    []any{map[string]any{"name": "a", "price": 1.5}},
+		"extra":    map[string]any{"gift": map[string]any{"name": "b", "price": 0.0}},
+		"ship":     nil,
+		"Plain":    false,
+	}, m)
+
+	// 嵌入的结构体指针为 nil 时忽略其字段
+	o.TestMeta = nil
+	o.Ship = &testItem{Name: "c"}
+
+	m, err = StructToMap(o)
+	ass.Nil(err)
+	ass.NotContains(m, "source")
+	ass.Equal(map[string]any{"name": "c", "price": 0.0}, m["ship"])
+
+	_, err = StructToMap(1)
+	ass.Error(err)
+
+	_, err = StructToMap((*testOrder)(nil))
+	ass.Error(err)
+}
+
+func TestStructToMapWithOptions(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	type query struct {
+		Name  string `db:"name"`
+		Age   int    `db:"age,omitempty"`
+		Email string `json:"email"`
+	}
+
+	m, err := StructToMapWithOptions(query{Name: "a", Email: "b"}, StructOptions{TagName: "db"})
+	ass.Nil(err)
+	ass.Equal(map[string]any{"name": "a", "Email": "b"}, m)
+}
+
+func TestMapToStruct(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	m := decodeTestJSON(`{
+		"id": 1,
+		"created": "2023-01-02T03:04:05Z",
+		"source": "web",
+		"customer": 42,
+		"items": [{"name": "a", "price": "1.5"}, {"name": "b", "price": 2}],
+		"extra": {"gift": {"name": "c"}},
+		"ship": {"name": "d"},
+		"tags": ["x", true],
+		"Secret": "s",
+		"Plain": "true",
+		"unknown": 1
+	}`)
+
+	var o testOrder
+	ass.Nil(MapToStruct(m, &o))
+
+	ass.Equal(testOrder{
+		testBase: testBase{ID: 1, Created: time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)},
+		TestMeta: &TestMeta{Source: "web"},
+		Customer: "42",
+		Items:    []testItem{{Name: "a", Price: 1.5}, {Name: "b", Price: 2}},
+		Extra:    map[string]testItem{"gift": {Name: "c"}},
+		Ship:     &testItem{Name: "d"},
+		Tags:     []string{"x", "true"},
+		Plain:    true,
+	}, o)
+
+	// 往返转换
+	m, err := StructToMap(o)
+	ass.Nil(err)
+
+	var o2 testOrder
+	ass.Nil(MapToStruct(m, &o2))
+	ass.Equal(o, o2)
+
+	// nil 设置为零值，不存在的字段保持不变
+	ass.Nil(MapToStruct(map[string]any{"ship": nil}, &o2))
+	ass.Nil(o2.Ship)
+	ass.Equal("42", o2.Customer)
+
+	ass.Error(MapToStruct(m, o2))
+	ass.Error(MapToStruct(m, (*testOrder)(nil)))
+	ass.Error(MapToStruct(m, new(int)))
+}
+
+func TestMapToStruct_Coercion(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	type numbers struct {
+		I8   int8              `json:"i8"`
+		U    uint              `json:"u"`
+		F32  float32           `json:"f32"`
+		I    *int              `json:"i"`
+		S    string            `json:"s"`
+		Arr  [2]int            `json:"arr"`
+		M    map[string]int    `json:"m"`
+		Any  any               `json:"any"`
+		Dur  time.Duration     `json:"dur"`
+		Nums []json.Number     `json:"nums"`
+		Raw  map[string]string `json:"raw"`
+	}
+
+	var n numbers
+	ass.Nil(MapToStruct(map[string]any{
+		"i8":   json.Number("12"),
+		"u":    "7",
+		"f32":  3,
+		"i":    2.0,
+		"s":    1.25,
+		"arr":  []int{1, 2},
+		"m":    map[string]any{"a": "1"},
+		"any":  []any{1},
+		"dur":  int64(time.Second),
+		"nums": []any{"1", "2.5"},
+		"raw":  map[string]string{"a": "b"},
+	}, &n))
+
+	i := 2
+	ass.Equal(numbers{
+		I8:   12,
+		U:    7,
+		F32:  3,
+		I:    &i,
+		S:    "1.25",
+		Arr:  [2]int{1, 2},
+		M:    map[string]int{"a": 1},
+		Any:  []any{1},
+		Dur:  time.Second,
+		Nums: []json.Number{"1", "2.5"},
+		Raw:  map[string]string{"a": "b"},
+	}, n)
+}
+
+func TestMapToStruct_Errors(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	type target struct {
+		I8    int8       `json:"i8"`
+		U     uint       `json:"u"`
+		I     int        `json:"i"`
+		F32   float32    `json:"f32"`
+		B     bool       `json:"b"`
+		T     time.Time  `json:"t"`
+		Items []testItem `json:"items"`
+		Arr   [1]int     `json:"arr"`
+		S     string     `json:"s"`
+		Name  string     `json:"name"`
+	}
+
+	var v target
+	err := MapToStruct(map[string]any{
+		"i8":    300,
+		"u":     -1,
+		"i":     1.5,
+		"f32":   1e100,
+		"b":     "yes",
+		"t":     "bad",
+		"items": []any{map[string]any{"price": "x"}, 1},
+		"arr":   []any{1, 2},
+		"s":     []int{1},
+		"name":  "ok",
+	}, &v)
+
+	var se *StructError
+	ass.True(errors.As(err, &se))
+	ass.ElementsMatch([]string{"i8", "u", "i", "f32", "b", "t", "items[0].price", "items[1]", "arr", "s"}, se.Paths())
+
+	// 其余字段正常写入
+	ass.Equal("ok", v.Name)
+
+	var fe *FieldError
+	ass.True(errors.As(err, &fe))
+
+	var ne *strconv.NumError
+	ass.True(errors.As(err, &ne))
+	ass.True(errors.Is(err, strconv.ErrSyntax))
+
+	ass.Contains(err.Error(), "mapjez: 10 field error(s) occurred:")
+}
+
+func TestMapToStruct_EmbeddedUnexportedPointer(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	type meta struct {
+		Source string `json:"source"`
+	}
+
+	type target struct {
+		*meta
+		Name string `json:"name"`
+	}
+
+	var v target
+	err := MapToStruct(map[string]any{"source": "web", "name": "a"}, &v)
+
+	var se *StructError
+	ass.True(errors.As(err, &se))
+	ass.Equal([]string{"source"}, se.Paths())
+	ass.Equal("a", v.Name)
+}
+
+type testNode struct {
+	*testNode
+	X int
+}
+
+func TestStructToMap_RecursiveEmbedded(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	m, err := StructToMap(testNode{testNode: &testNode{X: 2}, X: 1})
+	ass.Nil(err)
+	ass.Equal(map[string]any{"X": 1}, m)
+
+	var v testNode
+	ass.Nil(MapToStruct(map[string]any{"X": 3}, &v))
+	ass.Equal(3, v.X)
+	ass.Nil(v.testNode)
+}
@@ -0,0 +1,310 @@
+package mapjez
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// SnapshotFormat 快照的编码格式。
+type SnapshotFormat int
+
+const (
+	// SnapshotJSON 使用 encoding/json 编码
+	SnapshotJSON SnapshotFormat = iota
+	// SnapshotGob 使用 encoding/gob 编码，key和value为接口类型时需要先调用 gob.Register
+	SnapshotGob
+)
+
+// TTLMapOptions TTLMap 的配置。
+type TTLMapOptions[K comparable, V any] struct {
+	TTL             time.Duration        // 默认的过期时间，<= 0 表示不过期
+	CleanupInterval time.Duration        // 后台清理过期元素的间隔，<= 0 表示不启动后台清理，只在访问时移除
+	OnExpire        func(key K, value V) // 元素过期被移除时调用，调用时不持有锁
+	Now             func() time.Time     // 获取当前时间，默认为 time.Now，可以在测试中替换
+}
+
+type ttlEntry[V any] struct {
+	value    V
+	expireAt time.Time // 零值表示不过期
+}
+
+// TTLMap 并发安全的带过期时间的map，过期的元素在访问时被移除，也可以通过后台清理定期移除。
+//
+// 需要使用 NewTTLMap 创建，启动了后台清理时，不再使用后需要调用 Close 停止后台 goroutine。
+type TTLMap[K comparable, V any] struct {
+	opts TTLMapOptions[K, V]
+	m    map[K]ttlEntry[V]
+	lock *sync.RWMutex
+
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce *sync.Once
+}
+
+// NewTTLMap 创建一个带过期时间的map，opts.CleanupInterval > 0 时会启动后台清理。
+func NewTTLMap[K comparable, V any](opts TTLMapOptions[K, V]) *TTLMap[K, V] {
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+
+	t := &TTLMap[K, V]{
+		opts:      opts,
+		m:         make(map[K]ttlEntry[V]),
+		lock:      new(sync.RWMutex),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+		closeOnce: new(sync.Once),
+	}
+
+	if opts.CleanupInterval > 0 {
+		go t.janitor()
+	} else {
+		close(t.done)
+	}
+
+	return t
+}
+
+func (t *TTLMap[K, V]) janitor() {
+	defer close(t.done)
+
+	ticker := time.NewTicker(t.opts.CleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			t.DeleteExpired()
+		case <-t.stop:
+			return
+		}
+	}
+}
+
+// Close 停止后台清理并等待其退出，可以重复调用，Close 后仍然可以正常读写。
+func (t *TTLMap[K, V]) Close() {
+	t.closeOnce.Do(func() {
+		close(t.stop)
+	})
+	<-t.done
+}
+
+func (t *TTLMap[K, V]) expired(e ttlEntry[V], now time.Time) bool {
+	return !e.expireAt.IsZero() && !now.Before(e.expireAt)
+}
+
+func (t *TTLMap[K, V]) notify(expired []Entry[K, V]) {
+	if t.opts.OnExpire == nil {
+		return
+	}
+
+	for _, e := range expired {
+		t.opts.OnExpire(e.Key, e.Value)
+	}
+}
+
+// Get 返回 key 对应的value，如果不存在或已过期，ok 为 false，已过期的元素会被移除。
+func (t *TTLMap[K, V]) Get(key K) (value V, ok bool) {
+	t.lock.RLock()
+	e, ok := t.m[key]
+	t.lock.RUnlock()
+
+	if !ok {
+		return
+	}
+
+	now := t.opts.Now()
+	if !t.expired(e, now) {
+		return e.value, true
+	}
+
+	t.lock.Lock()
+	// 加锁期间可能已被更新或移除
+	e, ok = t.m[key]
+	if ok && t.expired(e, now) {
+		delete(t.m, key)
+	} else {
+		ok = false
+	}
+	t.lock.Unlock()
+
+	if ok {
+		t.notify([]Entry[K, V]{{Key: key, Value: e.value}})
+	}
+
+	return value, false
+}
+
+// TTL 返回 key 的剩余过期时间，不过期时为 0，如果不存在或已过期，ok 为 false。
+func (t *TTLMap[K, V]) TTL(key K) (ttl time.Duration, ok bool) {
+	t.lock.RLock()
+	e, ok := t.m[key]
+	t.lock.RUnlock()
+
+	now := t.opts.Now()
+	if !ok || t.expired(e, now) {
+		return 0, false
+	}
+
+	if e.expireAt.IsZero() {
+		return 0, true
+	}
+
+	return e.expireAt.Sub(now), true
+}
+
+// Set 设置 key 对应的value，使用默认的过期时间。
+func (t *TTLMap[K, V]) Set(key K, value V) {
+	t.SetWithTTL(key, value, t.opts.TTL)
+}
+
+// SetWithTTL 设置 key 对应的value，并指定过期时间，ttl <= 0 表示不过期。
+func (t *TTLMap[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	e := ttlEntry[V]{value: value}
+	if ttl > 0 {
+		e.expireAt = t.opts.Now().Add(ttl)
+	}
+
+	t.lock.Lock()
+	t.m[key] = e
+	t.lock.Unlock()
+}
+
+// Deletes 通过key删除多个元素，不会调用 OnExpire。
+func (t *TTLMap[K, V]) Deletes(keys ...K) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for _, k := range keys {
+		delete(t.m, k)
+	}
+}
+
+// DeleteExpired 移除所有已过期的元素，返回移除的数量。
+func (t *TTLMap[K, V]) DeleteExpired() int {
+	now := t.opts.Now()
+
+	var expired []Entry[K, V]
+
+	t.lock.Lock()
+	for k, e := range t.m {
+		if t.expired(e, now) {
+			expired = append(expired, Entry[K, V]{Key: k, Value: e.value})
+			delete(t.m, k)
+		}
+	}
+	t.lock.Unlock()
+
+	t.notify(expired)
+
+	return len(expired)
+}
+
+// Len 返回元素数量，可能包含已过期但尚未移除的元素。
+func (t *TTLMap[K, V]) Len() int {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	return len(t.m)
+}
+
+// Keys 返回所有未过期的key，顺序不确定。
+func (t *TTLMap[K, V]) Keys() []K {
+	now := t.opts.Now()
+
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	result := make([]K, 0, len(t.m))
+	for k, e := range t.m {
+		if !t.expired(e, now) {
+			result = append(result, k)
+		}
+	}
+
+	return result
+}
+
+// ForEach 遍历所有未过期的元素，对每个元素调用 iteratee 函数，iteratee 中不能修改 TTLMap。
+func (t *TTLMap[K, V]) ForEach(iteratee func(key K, value V)) {
+	now := t.opts.Now()
+
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	for k, e := range t.m {
+		if !t.expired(e, now) {
+			iteratee(k, e.value)
+		}
+	}
+}
+
+// 快照中的一个元素
+type ttlSnapshotEntry[K comparable, V any] struct {
+	Key      K
+	Value    V
+	ExpireAt time.Time
+}
+
+// Snapshot 将所有未过期的元素及其过期时间写入 w，可以通过 Restore 恢复。
+func (t *TTLMap[K, V]) Snapshot(w io.Writer, format SnapshotFormat) error {
+	now := t.opts.Now()
+
+	t.lock.RLock()
+	entries := make([]ttlSnapshotEntry[K, V], 0, len(t.m))
+	for k, e := range t.m {
+		if !t.expired(e, now) {
+			entries = append(entries, ttlSnapshotEntry[K, V]{Key: k, Value: e.value, ExpireAt: e.expireAt})
+		}
+	}
+	t.lock.RUnlock()
+
+	switch format {
+	case SnapshotJSON:
+		return json.NewEncoder(w).Encode(entries)
+	case SnapshotGob:
+		return gob.NewEncoder(w).Encode(entries)
+	}
+
+	return fmt.Errorf("mapjez: unknown snapshot format %d", format)
+}
+
+// Restore 从 r 读取 Snapshot 写入的元素，已过期的元素会被忽略，key已存在时覆盖原有的value，返回恢复的数量。
+//
+// 读取失败时不做任何修改。
+func (t *TTLMap[K, V]) Restore(r io.Reader, format SnapshotFormat) (int, error) {
+	var entries []ttlSnapshotEntry[K, V]
+
+	var err error
+	switch format {
+	case SnapshotJSON:
+		err = json.NewDecoder(r).Decode(&entries)
+	case SnapshotGob:
+		err = gob.NewDecoder(r).Decode(&entries)
+	default:
+		err = fmt.Errorf("mapjez: unknown snapshot format %d", format)
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	now := t.opts.Now()
+	n := 0
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for _, e := range entries {
+		entry := ttlEntry[V]{value: e.Value, expireAt: e.ExpireAt}
+		if !t.expired(entry, now) {
+			t.m[e.Key] = entry
+			n++
+		}
+	}
+
+	return n, nil
+}
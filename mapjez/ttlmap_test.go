@@ -0,0 +1,245 @@
+package mapjez
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTTLMap(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	clock := &testClock{now: time.Unix(0, 0)}
+
+	var expired []string
+
+	m := NewTTLMap(TTLMapOptions[string, int]{
+		TTL: time.Minute,
+		OnExpire: func(key string, value int) {
+			expired = append(expired, key)
+		},
+		Now: clock.Now,
+	})
+	defer m.Close()
+
+	m.Set("a", 1)
+	m.SetWithTTL("b", 2, 2*time.Minute)
+	m.SetWithTTL("c", 3, 0)
+
+	v, ok := m.Get("a")
+	ass.True(ok)
+	ass.Equal(1, v)
+
+	ttl, ok := m.TTL("b")
+	ass.True(ok)
+	ass.Equal(2*time.Minute, ttl)
+
+	ttl, ok = m.TTL("c")
+	ass.True(ok)
+	ass.Equal(time.Duration(0), ttl)
+
+	_, ok = m.TTL("x")
+	ass.False(ok)
+
+	clock.Add(time.Minute)
+
+	// 过期但尚未移除
+	ass.Equal(3, m.Len())
+	ass.ElementsMatch([]string{"b", "c"}, m.Keys())
+
+	_, ok = m.TTL("a")
+	ass.False(ok)
+
+	// 读取时移除
+	_, ok = m.Get("a")
+	ass.False(ok)
+	ass.Equal(2, m.Len())
+	ass.Equal([]string{"a"}, expired)
+
+	_, ok = m.Get("a")
+	ass.False(ok)
+	ass.Equal([]string{"a"}, expired)
+
+	sum := 0
+	m.ForEach(func(key string, value int) {
+		sum += value
+	})
+	ass.Equal(5, sum)
+
+	clock.Add(time.Hour)
+
+	ass.Equal(1, m.DeleteExpired())
+	ass.Equal([]string{"a", "b"}, expired)
+	ass.Equal(0, m.DeleteExpired())
+
+	// 手动删除不会触发回调
+	m.Deletes("c", "x")
+	ass.Equal(0, m.Len())
+	ass.Equal([]string{"a", "b"}, expired)
+
+	// 重新设置会刷新过期时间
+	m.Set("d", 4)
+	clock.Add(30 * time.Second)
+	m.Set("d", 5)
+	clock.Add(45 * time.Second)
+
+	v, ok = m.Get("d")
+	ass.True(ok)
+	ass.Equal(5, v)
+}
+
+func TestTTLMap_Janitor(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	clock := &testClock{now: time.Unix(0, 0)}
+
+	var (
+		mu      sync.Mutex
+		expired []string
+	)
+
+	m := NewTTLMap(TTLMapOptions[string, int]{
+		TTL:             time.Minute,
+		CleanupInterval: time.Millisecond,
+		OnExpire: func(key string, value int) {
+			mu.Lock()
+			expired = append(expired, key)
+			mu.Unlock()
+		},
+		Now: clock.Now,
+	})
+
+	m.Set("a", 1)
+	m.SetWithTTL("b", 2, 0)
+
+	clock.Add(time.Minute)
+
+	ass.Eventually(func() bool {
+		return m.Len() == 1
+	}, time.Second, time.Millisecond)
+
+	mu.Lock()
+	ass.Equal([]string{"a"}, expired)
+	mu.Unlock()
+
+	m.Close()
+	m.Close()
+
+	// Close 后后台清理已停止，仍然可以读写
+	select {
+	case <-m.done:
+	default:
+		ass.Fail("janitor not stopped")
+	}
+
+	m.Set("c", 3)
+	clock.Add(time.Minute)
+	time.Sleep(5 * time.Millisecond)
+	ass.Equal(2, m.Len())
+
+	_, ok := m.Get("c")
+	ass.False(ok)
+}
+
+func TestTTLMap_Concurrent(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	m := NewTTLMap(TTLMapOptions[int, int]{
+		TTL:             time.Millisecond,
+		CleanupInterval: time.Millisecond,
+	})
+	defer m.Close()
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(v int) {
+			defer wg.Done()
+			m.Set(v, v)
+			m.Get(v)
+			m.TTL(v)
+			m.Keys()
+			m.DeleteExpired()
+		}(i)
+	}
+
+	wg.Wait()
+
+	ass.Eventually(func() bool {
+		return m.Len() == 0
+	}, time.Second, time.Millisecond)
+}
+
+func TestTTLMap_Snapshot(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	clock := &testClock{now: time.Unix(1000, 0).UTC()}
+
+	for _, format := range []SnapshotFormat{SnapshotJSON, SnapshotGob} {
+		m := NewTTLMap(TTLMapOptions[string, []int]{TTL: time.Minute, Now: clock.Now})
+
+		m.Set("a", []int{1})
+		m.SetWithTTL("b", []int{2}, time.Hour)
+		m.SetWithTTL("c", []int{3}, 0)
+		m.SetWithTTL("d", []int{4}, time.Second)
+
+		clock.Add(time.Second)
+
+		var buf bytes.Buffer
+		ass.Nil(m.Snapshot(&buf, format))
+
+		clock.Add(time.Minute)
+
+		r := NewTTLMap(TTLMapOptions[string, []int]{Now: clock.Now})
+		r.SetWithTTL("b", []int{0}, 0)
+		r.SetWithTTL("e", []int{5}, 0)
+
+		// a 在恢复时已过期，d 在快照时已过期
+		n, err := r.Restore(&buf, format)
+		ass.Nil(err)
+		ass.Equal(2, n)
+		ass.ElementsMatch([]string{"b", "c", "e"}, r.Keys())
+
+		v, _ := r.Get("b")
+		ass.Equal([]int{2}, v)
+
+		ttl, _ := r.TTL("b")
+		ass.Equal(time.Hour-time.Minute-time.Second, ttl)
+
+		ttl, ok := r.TTL("c")
+		ass.True(ok)
+		ass.Equal(time.Duration(0), ttl)
+	}
+}
+
+func TestTTLMap_SnapshotError(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	m := NewTTLMap(TTLMapOptions[string, int]{})
+	m.Set("a", 1)
+
+	var buf bytes.Buffer
+	ass.Error(m.Snapshot(&buf, SnapshotFormat(9)))
+
+	_, err := m.Restore(strings.NewReader(`[]`), SnapshotFormat(9))
+	ass.Error(err)
+
+	// 读取失败时不做任何修改
+	n, err := m.Restore(strings.NewReader(`[{"Key":"b","Value":2},{"Key":"c","Value":"x"}]`), SnapshotJSON)
+	ass.Error(err)
+	ass.Equal(0, n)
+	ass.Equal([]string{"a"}, m.Keys())
+
+	_, err = m.Restore(strings.NewReader(`bad`), SnapshotGob)
+	ass.Error(err)
+}
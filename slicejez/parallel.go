@@ -0,0 +1,207 @@
+package slicejez
+
+import (
+	"context"
+	"runtime"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/dengrandpa/jez/internal/multierr"
+)
+
+// ParallelOptions 并发执行的配置。
+type ParallelOptions struct {
+	// Workers 最大并发数，<= 0 时为 runtime.GOMAXPROCS(0)
+	Workers int
+
+	// CollectErrors 为 false 时，第一个错误会取消其余元素的执行并返回该错误，
+	// 为 true 时，执行所有元素并通过 *MultiError 返回所有错误
+	CollectErrors bool
+}
+
+// IndexError 记录失败元素的索引及原因
+type IndexError struct {
+	Index int
+	Err   error
+}
+
+// Error 返回失败元素的索引及原因
+func (e *IndexError) Error() string {
+	return "index " + strconv.Itoa(e.Index) + ": " + e.Err.Error()
+}
+
+// Unwrap 返回 iteratee 返回的原始错误
+func (e *IndexError) Unwrap() error {
+	return e.Err
+}
+
+// MultiError ParallelOptions.CollectErrors 为 true 时返回的聚合错误，按索引升序记录每个失败的元素及其原因
+//
+// errors.Is、errors.As 会检查每个元素的错误，可以用来判断是否有元素因某个原因失败。
+type MultiError struct {
+	Errors []*IndexError
+}
+
+// Error 返回失败元素的数量，之后每行一个失败元素的索引及原因
+func (e *MultiError) Error() string {
+	return multierr.Format("slicejez", "error(s)", e.Errors)
+}
+
+// Indexes 返回所有失败元素的索引
+func (e *MultiError) Indexes() []int {
+	indexes := make([]int, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		indexes = append(indexes, err.Index)
+	}
+	return indexes
+}
+
+// Is 判断是否有任意一个失败元素的错误匹配 target
+func (e *MultiError) Is(target error) bool {
+	return multierr.Is(e.Errors, target)
+}
+
+// As 查找第一个可以赋值给 target 的失败元素的错误
+func (e *MultiError) As(target any) bool {
+	return multierr.As(e.Errors, target)
+}
+
+// 对 [0, n) 的每个索引并发调用 fn
+//
+// ctx 被取消时不再执行剩余的索引，等待执行中的 fn 返回后返回 ctx.Err()。
+func parallel(ctx context.Context, n int, opts ParallelOptions, fn func(ctx context.Context, index int) error) error {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	if workers > n {
+		workers = n
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu    sync.Mutex
+		first *IndexError
+		me    = new(MultiError)
+		wg    sync.WaitGroup
+	)
+
+	indexes := make(chan int)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+				// 已取消时跳过
+				if runCtx.Err() != nil {
+					continue
+				}
+
+				err := fn(runCtx, i)
+				if err == nil {
+					continue
+				}
+
+				mu.Lock()
+				ie := &IndexError{Index: i, Err: err}
+				if opts.CollectErrors {
+					me.Errors = append(me.Errors, ie)
+				} else if first == nil {
+					first = ie
+					cancel()
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+dispatch:
+	for i := 0; i < n; i++ {
+		select {
+		case indexes <- i:
+		case <-runCtx.Done():
+			break dispatch
+		}
+	}
+
+	close(indexes)
+	wg.Wait()
+
+	// 外部 ctx 被取消时，元素的错误可能是取消导致的，优先返回 ctx.Err()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if first != nil {
+		return first
+	}
+
+	if len(me.Errors) == 0 {
+		return nil
+	}
+
+	sort.Slice(me.Errors, func(i, j int) bool {
+		return me.Errors[i].Index < me.Errors[j].Index
+	})
+
+	return me
+}
+
+// ParallelForEach 并发遍历切片并为每个元素调用 iteratee 函数，最多同时执行 opts.Workers 个。
+//
+// opts.CollectErrors 为 false 时，返回第一个失败元素的 *IndexError，并取消其余元素的执行，iteratee 收到的 ctx 会被取消；
+// 为 true 时返回包含所有失败元素的 *MultiError。ctx 被取消时返回 ctx.Err()。
+func ParallelForEach[T any](ctx context.Context, list []T, opts ParallelOptions, iteratee func(ctx context.Context, index int, item T) error) error {
+	return parallel(ctx, len(list), opts, func(ctx context.Context, index int) error {
+		return iteratee(ctx, index, list[index])
+	})
+}
+
+// ParallelMap 并发遍历切片并为每个元素调用 iteratee 函数，返回与 list 顺序相同的结果，错误处理与 ParallelForEach 相同。
+//
+// opts.CollectErrors 为 true 时，仍然返回所有结果，失败元素的位置为零值；否则出错时返回 nil。
+func ParallelMap[T, U any](ctx context.Context, list []T, opts ParallelOptions, iteratee func(ctx context.Context, index int, item T) (U, error)) ([]U, error) {
+	result := make([]U, len(list))
+
+	err := parallel(ctx, len(list), opts, func(ctx context.Context, index int) error {
+		v, err := iteratee(ctx, index, list[index])
+		if err != nil {
+			return err
+		}
+
+		result[index] = v
+		return nil
+	})
+
+	// 只有 CollectErrors 为 true 时才会返回 *MultiError，此时仍然返回结果
+	if _, ok := err.(*MultiError); err != nil && !ok {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// ParallelFilter 并发遍历切片并为每个元素调用 iteratee 函数，只返回调用结果为 true 的元素，保持原有顺序，错误处理与 ParallelMap 相同。
+//
+// opts.CollectErrors 为 true 时，失败的元素不会包含在结果中。
+func ParallelFilter[T any](ctx context.Context, list []T, opts ParallelOptions, iteratee func(ctx context.Context, index int, item T) (bool, error)) ([]T, error) {
+	keep, err := ParallelMap(ctx, list, opts, iteratee)
+	if keep == nil {
+		return nil, err
+	}
+
+	result := make([]T, 0)
+	for i, v := range list {
+		if keep[i] {
+			result = append(result, v)
+		}
+	}
+
+	return result, err
+}
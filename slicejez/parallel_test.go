@@ -0,0 +1,246 @@
+package slicejez
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errTestParallel = errors.New("parallel test error")
+
+func TestParallelMap(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	list := make([]int, 100)
+	for i := range list {
+		list[i] = i
+	}
+
+	var running, peak int32
+
+	result, err := ParallelMap(context.Background(), list, ParallelOptions{Workers: 4}, func(ctx context.Context, index int, item int) (string, error) {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+
+		time.Sleep(time.Millisecond)
+		return strconv.Itoa(item), nil
+	})
+
+	ass.Nil(err)
+	ass.Len(result, 100)
+
+	// 保持原有顺序
+	for i, v := range result {
+		ass.Equal(strconv.Itoa(i), v)
+	}
+
+	// 并发数不超过 Workers
+	ass.LessOrEqual(atomic.LoadInt32(&peak), int32(4))
+	ass.Greater(atomic.LoadInt32(&peak), int32(1))
+
+	result, err = ParallelMap(context.Background(), []int{}, ParallelOptions{}, func(ctx context.Context, index int, item int) (string, error) {
+		return "", nil
+	})
+	ass.Nil(err)
+	ass.Equal([]string{}, result)
+}
+
+func TestParallelMap_FailFast(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	list := make([]int, 100)
+
+	var calls int32
+
+	result, err := ParallelMap(context.Background(), list, ParallelOptions{Workers: 2}, func(ctx context.Context, index int, item int) (int, error) {
+		atomic.AddInt32(&calls, 1)
+
+		if index == 3 {
+			return 0, errTestParallel
+		}
+
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(time.Millisecond):
+			return item, nil
+		}
+	})
+
+	ass.Nil(result)
+	ass.ErrorIs(err, errTestParallel)
+
+	var ie *IndexError
+	ass.True(errors.As(err, &ie))
+	ass.Equal(3, ie.Index)
+	ass.Equal("index 3: parallel test error", err.Error())
+
+	// 出错后不再执行剩余的元素
+	ass.Less(atomic.LoadInt32(&calls), int32(100))
+}
+
+func TestParallelMap_CollectErrors(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	list := []int{1, 2, 3, 4, 5, 6}
+
+	result, err := ParallelMap(context.Background(), list, ParallelOptions{Workers: 3, CollectErrors: true}, func(ctx context.Context, index int, item int) (int, error) {
+		if item%2 == 0 {
+			return 0, errTestParallel
+		}
+		return item * 10, nil
+	})
+
+	ass.Equal([]int{10, 0, 30, 0, 50, 0}, result)
+
+	var me *MultiError
+	ass.True(errors.As(err, &me))
+	ass.Equal([]int{1, 3, 5}, me.Indexes())
+	ass.ErrorIs(err, errTestParallel)
+
+	var ie *IndexError
+	ass.True(errors.As(err, &ie))
+	ass.Equal(1, ie.Index)
+
+	ass.Equal("slicejez: 3 error(s) occurred:\n\tindex 1: parallel test error\n\tindex 3: parallel test error\n\tindex 5: parallel test error", err.Error())
+
+	ass.False(errors.Is(err, context.Canceled))
+}
+
+func TestParallelMap_Context(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var calls int32
+
+	list := make([]int, 100)
+
+	result, err := ParallelMap(ctx, list, ParallelOptions{Workers: 2, CollectErrors: true}, func(ctx context.Context, index int, item int) (int, error) {
+		if atomic.AddInt32(&calls, 1) == 5 {
+			cancel()
+		}
+		return item, nil
+	})
+
+	ass.Nil(result)
+	ass.ErrorIs(err, context.Canceled)
+	ass.Less(atomic.LoadInt32(&calls), int32(100))
+
+	// 已取消的 ctx
+	err = ParallelForEach(ctx, list, ParallelOptions{}, func(ctx context.Context, index int, item int) error {
+		return nil
+	})
+	ass.ErrorIs(err, context.Canceled)
+
+	// 外部 ctx 被取消后元素返回的错误不会覆盖 ctx.Err()
+	ctx, cancel = context.WithCancel(context.Background())
+
+	err = ParallelForEach(ctx, list, ParallelOptions{Workers: 1}, func(ctx context.Context, index int, item int) error {
+		cancel()
+		return errTestParallel
+	})
+	ass.Equal(context.Canceled, err)
+}
+
+func TestParallelForEach(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	list := make([]int, 50)
+	for i := range list {
+		list[i] = i
+	}
+
+	var sum int64
+
+	err := ParallelForEach(context.Background(), list, ParallelOptions{}, func(ctx context.Context, index int, item int) error {
+		atomic.AddInt64(&sum, int64(item))
+		return nil
+	})
+
+	ass.Nil(err)
+	ass.Equal(int64(1225), sum)
+
+	err = ParallelForEach(context.Background(), list, ParallelOptions{Workers: 100, CollectErrors: true}, func(ctx context.Context, index int, item int) error {
+		if item >= 48 {
+			return errTestParallel
+		}
+		return nil
+	})
+
+	var me *MultiError
+	ass.True(errors.As(err, &me))
+	ass.Equal([]int{48, 49}, me.Indexes())
+}
+
+func TestParallelFilter(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	list := []int{1, 2, 3, 4, 5, 6}
+
+	result, err := ParallelFilter(context.Background(), list, ParallelOptions{Workers: 2}, func(ctx context.Context, index int, item int) (bool, error) {
+		return item%2 == 0, nil
+	})
+
+	ass.Nil(err)
+	ass.Equal([]int{2, 4, 6}, result)
+
+	// 失败的元素不会包含在结果中
+	result, err = ParallelFilter(context.Background(), list, ParallelOptions{CollectErrors: true}, func(ctx context.Context, index int, item int) (bool, error) {
+		if item == 4 {
+			return true, errTestParallel
+		}
+		return item%2 == 0, nil
+	})
+
+	ass.ErrorIs(err, errTestParallel)
+	ass.Equal([]int{2, 6}, result)
+
+	result, err = ParallelFilter(context.Background(), list, ParallelOptions{}, func(ctx context.Context, index int, item int) (bool, error) {
+		return false, errTestParallel
+	})
+
+	ass.ErrorIs(err, errTestParallel)
+	ass.Nil(result)
+}
+
+func TestSafeSlice_Parallel(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	ss := buildTestSafeSlice(0, 10)
+
+	// 执行期间不持有锁，可以修改 SafeSlice
+	err := ss.ParallelForEach(context.Background(), ParallelOptions{Workers: 3}, func(ctx context.Context, index int, item int) error {
+		ss.Append(item + 10)
+		return nil
+	})
+
+	ass.Nil(err)
+	ass.Equal(20, ss.Len())
+
+	result, err := ss.ParallelFilter(context.Background(), ParallelOptions{}, func(ctx context.Context, index int, item int) (bool, error) {
+		return item >= 18, nil
+	})
+
+	ass.Nil(err)
+	ass.ElementsMatch([]int{18, 19}, result)
+}
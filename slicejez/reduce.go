@@ -0,0 +1,136 @@
+package slicejez
+
+import (
+	"golang.org/x/exp/constraints"
+)
+
+// Reduce 从左到右遍历切片，将 iteratee 的返回值作为下一次调用的 acc，返回最后的结果，切片为空时返回 initial。
+func Reduce[T, R any](list []T, initial R, iteratee func(acc R, index int, item T) R) R {
+	acc := initial
+	for i, v := range list {
+		acc = iteratee(acc, i, v)
+	}
+
+	return acc
+}
+
+// ReduceRight 与 Reduce 相同，但从右到左遍历切片。
+func ReduceRight[T, R any](list []T, initial R, iteratee func(acc R, index int, item T) R) R {
+	acc := initial
+	for i := len(list) - 1; i >= 0; i-- {
+		acc = iteratee(acc, i, list[i])
+	}
+
+	return acc
+}
+
+// Scan 与 Reduce 相同，但返回每一步的结果，长度与 list 相同，不包含 initial。
+func Scan[T, R any](list []T, initial R, iteratee func(acc R, index int, item T) R) []R {
+	result := make([]R, len(list))
+
+	acc := initial
+	for i, v := range list {
+		acc = iteratee(acc, i, v)
+		result[i] = acc
+	}
+
+	return result
+}
+
+// Sum 返回所有元素的和，切片为空时返回 0。
+func Sum[T constraints.Integer | constraints.Float](list []T) T {
+	var sum T
+	for _, v := range list {
+		sum += v
+	}
+
+	return sum
+}
+
+// Product 返回所有元素的积，切片为空时返回 1。
+func Product[T constraints.Integer | constraints.Float](list []T) T {
+	var product T = 1
+	for _, v := range list {
+		product *= v
+	}
+
+	return product
+}
+
+// Mean 返回所有元素的平均值，使用 float64 计算，切片为空时 ok 为 false。
+func Mean[T constraints.Integer | constraints.Float](list []T) (mean float64, ok bool) {
+	if len(list) == 0 {
+		return 0, false
+	}
+
+	var sum float64
+	for _, v := range list {
+		sum += float64(v)
+	}
+
+	return sum / float64(len(list)), true
+}
+
+// MinOr 返回最小值，切片为空时返回 fallback。
+func MinOr[T constraints.Ordered](list []T, fallback T) T {
+	if len(list) == 0 {
+		return fallback
+	}
+
+	return Min(list)
+}
+
+// MaxOr 返回最大值，切片为空时返回 fallback。
+func MaxOr[T constraints.Ordered](list []T, fallback T) T {
+	if len(list) == 0 {
+		return fallback
+	}
+
+	return Max(list)
+}
+
+// MinBy 返回 key 函数的返回值最小的元素，有多个时返回第一个，切片为空时 ok 为 false。
+func MinBy[T any, K constraints.Ordered](list []T, key func(item T) K) (item T, ok bool) {
+	if len(list) == 0 {
+		return
+	}
+
+	item = list[0]
+	min := key(item)
+
+	for i := 1; i < len(list); i++ {
+		if k := key(list[i]); k < min {
+			item, min = list[i], k
+		}
+	}
+
+	return item, true
+}
+
+// MaxBy 返回 key 函数的返回值最大的元素，有多个时返回第一个，切片为空时 ok 为 false。
+func MaxBy[T any, K constraints.Ordered](list []T, key func(item T) K) (item T, ok bool) {
+	if len(list) == 0 {
+		return
+	}
+
+	item = list[0]
+	max := key(item)
+
+	for i := 1; i < len(list); i++ {
+		if k := key(list[i]); k > max {
+			item, max = list[i], k
+		}
+	}
+
+	return item, true
+}
+
+// CountBy 遍历切片，按 iteratee 返回的key统计元素数量。
+func CountBy[T any, K comparable](list []T, iteratee func(index int, item T) K) map[K]int {
+	result := make(map[K]int)
+	for i, v := range list {
+		result[iteratee(i, v)]++
+	}
+
+	return result
+}
@@ -0,0 +1,106 @@
+package slicejez
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReduce(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	list := []int{1, 2, 3}
+
+	join := func(acc string, index int, item int) string {
+		return acc + strconv.Itoa(index) + ":" + strconv.Itoa(item) + " "
+	}
+
+	ass.Equal("0:1 1:2 2:3 ", Reduce(list, "", join))
+	ass.Equal("2:3 1:2 0:1 ", ReduceRight(list, "", join))
+	ass.Equal("x", Reduce([]int{}, "x", join))
+	ass.Equal("x", ReduceRight[int](nil, "x", join))
+
+	sum := func(acc int, index int, item int) int {
+		return acc + item
+	}
+
+	ass.Equal([]int{11, 13, 16}, Scan(list, 10, sum))
+	ass.Equal([]int{}, Scan([]int{}, 10, sum))
+}
+
+func TestSum(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	ass.Equal(6, Sum([]int{1, 2, 3}))
+	ass.Equal(0, Sum([]int{}))
+	ass.Equal(4.0, Sum([]float64{1.5, 2.5}))
+
+	ass.Equal(24, Product([]int{2, 3, 4}))
+	ass.Equal(1, Product([]int{}))
+	ass.Equal(uint8(6), Product([]uint8{2, 3}))
+
+	mean, ok := Mean([]int{1, 2, 4})
+	ass.True(ok)
+	ass.InDelta(7.0/3, mean, 1e-9)
+
+	mean, ok = Mean([]int{})
+	ass.False(ok)
+	ass.Equal(0.0, mean)
+
+	// 使用 float64 计算，不会溢出
+	mean, _ = Mean([]int8{100, 100})
+	ass.Equal(100.0, mean)
+}
+
+func TestMinOr(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	ass.Equal(1, MinOr([]int{3, 1, 2}, -1))
+	ass.Equal(-1, MinOr([]int{}, -1))
+	ass.Equal(3, MaxOr([]int{3, 1, 2}, -1))
+	ass.Equal("none", MaxOr(nil, "none"))
+}
+
+func TestMinBy(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	list := []testUser{{"a", 20}, {"b", 10}, {"c", 30}, {"d", 10}, {"e", 30}}
+	age := func(item testUser) int {
+		return item.Age
+	}
+
+	item, ok := MinBy(list, age)
+	ass.True(ok)
+	ass.Equal(testUser{"b", 10}, item)
+
+	item, ok = MaxBy(list, age)
+	ass.True(ok)
+	ass.Equal(testUser{"c", 30}, item)
+
+	item, ok = MinBy([]testUser{}, age)
+	ass.False(ok)
+	ass.Equal(testUser{}, item)
+
+	_, ok = MaxBy[testUser, int](nil, age)
+	ass.False(ok)
+}
+
+func TestCountBy(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	list := []int{1, 2, 3, 4, 5}
+
+	ass.Equal(map[bool]int{true: 2, false: 3}, CountBy(list, func(index int, item int) bool {
+		return item%2 == 0
+	}))
+
+	ass.Equal(map[string]int{}, CountBy([]int{}, func(index int, item int) string {
+		return ""
+	}))
+}
@@ -1,6 +1,7 @@
 package slicejez
 
 import (
+	"context"
 	"sync"
 )
 
@@ -39,6 +40,16 @@ func (s *SafeSlice[T]) Filter(iteratee func(index int, item T) bool) []T {
 	return Filter(s.list, iteratee)
 }
 
+// ParallelForEach 对切片的副本调用 ParallelForEach，执行期间不持有锁，iteratee 中可以修改 SafeSlice。
+func (s *SafeSlice[T]) ParallelForEach(ctx context.Context, opts ParallelOptions, iteratee func(ctx context.Context, index int, item T) error) error {
+	return ParallelForEach(ctx, s.Load(), opts, iteratee)
+}
+
+// ParallelFilter 对切片的副本调用 ParallelFilter，执行期间不持有锁，iteratee 中可以修改 SafeSlice。
+func (s *SafeSlice[T]) ParallelFilter(ctx context.Context, opts ParallelOptions, iteratee func(ctx context.Context, index int, item T) (bool, error)) ([]T, error) {
+	return ParallelFilter(ctx, s.Load(), opts, iteratee)
+}
+
 // Append 添加元素到切片。
 func (s *SafeSlice[T]) Append(items ...T) {
 	s.lock.Lock()
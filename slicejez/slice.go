@@ -3,6 +3,8 @@ package slicejez
 
 import (
 	"golang.org/x/exp/constraints"
+
+	"github.com/dengrandpa/jez/mapjez"
 )
 
 // ForEach 遍历切片并为每个元素调用 iteratee 函数。
@@ -195,6 +197,25 @@ func UniqueBy[T, U comparable](list []T, iteratee func(index int, item T) U) []U
 	return result
 }
 
+// UniqueByKey 按 key 函数的返回值去重，返回原有的元素，保留第一次出现的元素，与 UniqueBy 不同，UniqueBy 返回的是key。
+func UniqueByKey[T any, K comparable](list []T, key func(item T) K) []T {
+	result := make([]T, 0, len(list))
+	seen := make(mapjez.Set[K], len(list))
+
+	for _, item := range list {
+		k := key(item)
+
+		if seen.Has(k) {
+			continue
+		}
+
+		seen.Add(k)
+		result = append(result, item)
+	}
+
+	return result
+}
+
 // UniqueNonzero 删除重复元素及零值。
 func UniqueNonzero[T comparable](list []T) []T {
 	result := make([]T, 0, len(list))
@@ -292,25 +313,17 @@ func Difference[T comparable](list1, list2 []T) []T {
 
 	result := make([]T, 0, len(list1)+len(list2))
 
-	l1 := make(map[T]struct{}, len(list1))
-	l2 := make(map[T]struct{}, len(list2))
-
-	for _, v := range list1 {
-		l1[v] = struct{}{}
-	}
-
-	for _, v := range list2 {
-		l2[v] = struct{}{}
-	}
+	l1 := mapjez.NewSet(list1...)
+	l2 := mapjez.NewSet(list2...)
 
 	for _, v := range list1 {
-		if _, ok := l2[v]; !ok {
+		if !l2.Has(v) {
 			result = append(result, v)
 		}
 	}
 
 	for _, v := range list2 {
-		if _, ok := l1[v]; !ok {
+		if !l1.Has(v) {
 			result = append(result, v)
 		}
 	}
@@ -331,28 +344,20 @@ func DifferenceUnique[T comparable](list1, list2 []T) []T {
 
 	result := make([]T, 0, len(list1)+len(list2))
 
-	l1 := make(map[T]struct{}, len(list1))
-	l2 := make(map[T]struct{}, len(list2))
+	l1 := mapjez.NewSet(list1...)
+	l2 := mapjez.NewSet(list2...)
 
 	for _, v := range list1 {
-		l1[v] = struct{}{}
-	}
-
-	for _, v := range list2 {
-		l2[v] = struct{}{}
-	}
-
-	for _, v := range list1 {
-		if _, ok := l2[v]; !ok {
+		if !l2.Has(v) {
 			result = append(result, v)
-			l2[v] = struct{}{}
+			l2.Add(v)
 		}
 	}
 
 	for _, v := range list2 {
-		if _, ok := l1[v]; !ok {
+		if !l1.Has(v) {
 			result = append(result, v)
-			l1[v] = struct{}{}
+			l1.Add(v)
 		}
 	}
 
@@ -366,16 +371,12 @@ func Intersection[T comparable](list1, list2 []T) []T {
 	}
 
 	result := make([]T, 0, len(list1))
-	exist := make(map[T]struct{}, len(list1)+len(list2))
-
-	for _, v := range list2 {
-		exist[v] = struct{}{}
-	}
+	exist := mapjez.NewSet(list2...)
 
 	for _, v := range list1 {
-		if _, ok := exist[v]; ok {
+		if exist.Has(v) {
 			result = append(result, v)
-			delete(exist, v)
+			exist.Remove(v)
 		}
 	}
 
@@ -399,25 +400,95 @@ func MutualDifference[T comparable](list1, list2 []T) ([]T, []T) {
 	left := make([]T, 0, len(list1)+len(list2))
 	right := make([]T, 0, len(list1)+len(list2))
 
-	seenLeft := make(map[T]struct{}, len(list1))
-	seenRight := make(map[T]struct{}, len(list2))
+	seenLeft := mapjez.NewSet(list1...)
+	seenRight := mapjez.NewSet(list2...)
 
 	for _, v := range list1 {
-		seenLeft[v] = struct{}{}
+		if !seenRight.Has(v) {
+			left = append(left, v)
+		}
 	}
 
 	for _, v := range list2 {
-		seenRight[v] = struct{}{}
+		if !seenLeft.Has(v) {
+			right = append(right, v)
+		}
+	}
+
+	return left, right
+}
+
+// 返回所有元素的key组成的集合
+func keySet[T any, K comparable](list []T, key func(item T) K) mapjez.Set[K] {
+	result := make(mapjez.Set[K], len(list))
+	for _, item := range list {
+		result.Add(key(item))
+	}
+	return result
+}
+
+// DifferenceBy 按 key 函数的返回值计算差集，返回原有的元素，结果不去重，与 Difference 相同，包含两个方向的差异。
+func DifferenceBy[T any, K comparable](list1, list2 []T, key func(item T) K) []T {
+	left, right := MutualDifferenceBy(list1, list2, key)
+	return append(left, right...)
+}
+
+// IntersectionBy 按 key 函数的返回值计算交集，返回 list1 中的元素，结果按key唯一，保留第一次出现的元素。
+func IntersectionBy[T any, K comparable](list1, list2 []T, key func(item T) K) []T {
+	result := make([]T, 0)
+	if len(list1) == 0 || len(list2) == 0 {
+		return result
 	}
 
+	exist := keySet(list2, key)
+
 	for _, v := range list1 {
-		if _, ok := seenRight[v]; !ok {
+		if k := key(v); exist.Has(k) {
+			result = append(result, v)
+			exist.Remove(k)
+		}
+	}
+
+	return result
+}
+
+// UnionBy 按 key 函数的返回值计算并集，返回原有的元素，结果按key唯一，保留第一次出现的元素。
+func UnionBy[T any, K comparable](list1, list2 []T, key func(item T) K) []T {
+	result := make([]T, 0, len(list1)+len(list2))
+	seen := make(mapjez.Set[K], len(list1)+len(list2))
+
+	for _, list := range [][]T{list1, list2} {
+		for _, v := range list {
+			if k := key(v); !seen.Has(k) {
+				seen.Add(k)
+				result = append(result, v)
+			}
+		}
+	}
+
+	return result
+}
+
+// MutualDifferenceBy 按 key 函数的返回值计算差异，返回原有的元素，结果不去重，返回的切片都是新分配的。
+//
+// 返回值：
+//   - list1 中存在， list2 中不存在。
+//   - list2 中存在， list1 中不存在。
+func MutualDifferenceBy[T any, K comparable](list1, list2 []T, key func(item T) K) ([]T, []T) {
+	left := make([]T, 0, len(list1))
+	right := make([]T, 0, len(list2))
+
+	seenLeft := keySet(list1, key)
+	seenRight := keySet(list2, key)
+
+	for _, v := range list1 {
+		if !seenRight.Has(key(v)) {
 			left = append(left, v)
 		}
 	}
 
 	for _, v := range list2 {
-		if _, ok := seenLeft[v]; !ok {
+		if !seenLeft.Has(key(v)) {
 			right = append(right, v)
 		}
 	}
@@ -549,7 +620,7 @@ func FindUniqueDuplicates[T comparable](list []T) []T {
 	return result
 }
 
-// Min 返回最小值
+// Min 返回最小值，切片为空时返回零值，需要区分时使用 MinOr。
 func Min[T constraints.Ordered](list []T) T {
 	var min T
 
@@ -568,7 +639,7 @@ func Min[T constraints.Ordered](list []T) T {
 	return min
 }
 
-// Max 返回最大值
+// Max 返回最大值，切片为空时返回零值，需要区分时使用 MaxOr。
 func Max[T constraints.Ordered](list []T) T {
 	var max T
 
@@ -692,3 +763,107 @@ func InsertAt[T any](slice []T, index int, value ...T) []T {
 	slice = append(slice[:index], append(value, slice[index:]...)...)
 	return slice
 }
+
+// Chunk 将切片按 size 分成多个子切片，最后一个子切片的长度可能小于 size，size <= 0 时返回空切片。
+//
+// 返回的子切片都是新分配的，修改不会影响 list，也不会相互影响。
+func Chunk[T any](list []T, size int) [][]T {
+	if size <= 0 {
+		return [][]T{}
+	}
+
+	result := make([][]T, 0, (len(list)+size-1)/size)
+
+	for i := 0; i < len(list); i += size {
+		end := i + size
+		if end > len(list) {
+			end = len(list)
+		}
+
+		chunk := make([]T, end-i)
+		copy(chunk, list[i:end])
+		result = append(result, chunk)
+	}
+
+	return result
+}
+
+// SlidingWindow 返回长度为 size 的滑动窗口，每次向后移动 step 个元素，只返回完整的窗口，size 或 step <= 0 时返回空切片。
+//
+// 返回的窗口都是新分配的，修改不会影响 list，也不会相互影响。
+func SlidingWindow[T any](list []T, size, step int) [][]T {
+	if size <= 0 || step <= 0 || len(list) < size {
+		return [][]T{}
+	}
+
+	result := make([][]T, 0, (len(list)-size)/step+1)
+
+	for i := 0; i+size <= len(list); i += step {
+		window := make([]T, size)
+		copy(window, list[i:i+size])
+		result = append(result, window)
+	}
+
+	return result
+}
+
+// Partition 遍历切片，将 predicate 返回 true 的元素放入 matched，其余的放入 rest，保持原有顺序。
+//
+// 返回的切片都是新分配的，修改不会影响 list。
+func Partition[T any](list []T, predicate func(index int, item T) bool) (matched, rest []T) {
+	matched = make([]T, 0)
+	rest = make([]T, 0)
+
+	for i, v := range list {
+		if predicate(i, v) {
+			matched = append(matched, v)
+		} else {
+			rest = append(rest, v)
+		}
+	}
+
+	return
+}
+
+// SplitBy 以 isDelimiter 返回 true 的元素作为分隔符拆分切片，分隔符不包含在结果中，与 strings.Split 相同，
+// n 个分隔符会得到 n+1 个子切片，相邻的分隔符之间为空切片，list 为空时返回空切片。
+//
+// 返回的子切片都是新分配的，修改不会影响 list，也不会相互影响。
+func SplitBy[T any](list []T, isDelimiter func(index int, item T) bool) [][]T {
+	result := make([][]T, 0)
+
+	if len(list) == 0 {
+		return result
+	}
+
+	start := 0
+
+	for i, v := range list {
+		if isDelimiter(i, v) {
+			part := make([]T, i-start)
+			copy(part, list[start:i])
+			result = append(result, part)
+			start = i + 1
+		}
+	}
+
+	part := make([]T, len(list)-start)
+	copy(part, list[start:])
+
+	return append(result, part)
+}
+
+// GroupBy 遍历切片，按 iteratee 返回的key分组，key按首次出现的顺序排列，组内元素保持原有顺序。
+//
+// 每组都是新分配的切片，修改不会影响 list。
+func GroupBy[T any, K comparable](list []T, iteratee func(index int, item T) K) *mapjez.OrderedMap[K, []T] {
+	result := mapjez.NewOrderedMap[K, []T]()
+
+	for i, v := range list {
+		k := iteratee(i, v)
+		group, _ := result.Get(k)
+		result.Set(k, append(group, v))
+	}
+
+	return result
+}
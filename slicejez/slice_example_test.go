@@ -1,8 +1,11 @@
 package slicejez
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strconv"
+	"sync/atomic"
 )
 
 func ExampleForEach() {
@@ -590,3 +593,634 @@ func ExampleInsertAt() {
 	// Output:
 	// [1 2 666 777 888 3 4 5]
 }
+
+func ExampleChunk() {
+	list := []int{1, 2, 3, 4, 5}
+
+	fmt.Println(Chunk(list, 2))
+
+	// Output:
+	// [[1 2] [3 4] [5]]
+}
+
+func ExampleSlidingWindow() {
+	list := []int{1, 2, 3, 4, 5}
+
+	fmt.Println(SlidingWindow(list, 3, 1))
+	fmt.Println(SlidingWindow(list, 2, 2))
+
+	// Output:
+	// [[1 2 3] [2 3 4] [3 4 5]]
+	// [[1 2] [3 4]]
+}
+
+func ExamplePartition() {
+	list := []int{1, 2, 3, 4, 5}
+
+	even, odd := Partition(list, func(index int, item int) bool {
+		return item%2 == 0
+	})
+
+	fmt.Println(even, odd)
+
+	// Output:
+	// [2 4] [1 3 5]
+}
+
+func ExampleSplitBy() {
+	list := []string{"a", "b", "|", "c", "|", "|", "d"}
+
+	parts := SplitBy(list, func(index int, item string) bool {
+		return item == "|"
+	})
+
+	fmt.Println(len(parts), parts)
+
+	// Output:
+	// 4 [[a b] [c] [] [d]]
+}
+
+func ExampleGroupBy() {
+	list := []string{"b1", "a1", "b2", "c1", "a2"}
+
+	groups := GroupBy(list, func(index int, item string) string {
+		return item[:1]
+	})
+
+	groups.ForEach(func(key string, value []string) {
+		fmt.Println(key, value)
+	})
+
+	// Output:
+	// b [b1 b2]
+	// a [a1 a2]
+	// c [c1]
+}
+
+func ExampleCompare() {
+	fmt.Println(Compare(1, 2), Compare("b", "b"), Compare(2.5, 1.5))
+
+	// Output:
+	// -1 0 1
+}
+
+func ExampleCompareBy() {
+	type user struct {
+		Name string
+		Age  int
+	}
+
+	list := []user{{"a", 20}, {"b", 30}, {"c", 20}}
+
+	// 按年龄降序，年龄相同时按名字升序
+	cmp := CompareBy(func(item user) int {
+		return item.Age
+	}).Desc().ThenBy(CompareBy(func(item user) string {
+		return item.Name
+	}))
+
+	SortWith(list, cmp)
+
+	fmt.Println(list)
+
+	// Output:
+	// [{b 30} {a 20} {c 20}]
+}
+
+func ExampleSortBy() {
+	type user struct {
+		Name string
+		Age  int
+	}
+
+	list := []user{{"a", 30}, {"b", 20}, {"c", 10}, {"d", 20}}
+
+	SortBy(list, func(item user) int {
+		return item.Age
+	})
+
+	fmt.Println(list)
+
+	// Output:
+	// [{c 10} {b 20} {d 20} {a 30}]
+}
+
+func ExampleSortWith() {
+	list := []int{3, 1, 2}
+
+	SortWith(list, Comparator[int](Compare[int]).Desc())
+
+	fmt.Println(list)
+
+	// Output:
+	// [3 2 1]
+}
+
+func ExampleTopK() {
+	list := []int{5, 1, 4, 2, 3}
+
+	fmt.Println(TopK(list, 3, Compare[int]))
+
+	// Output:
+	// [5 4 3]
+}
+
+func ExampleBottomK() {
+	list := []int{5, 1, 4, 2, 3}
+
+	fmt.Println(BottomK(list, 3, Compare[int]))
+
+	// Output:
+	// [1 2 3]
+}
+
+func ExampleBinarySearchBy() {
+	type user struct {
+		Name string
+		Age  int
+	}
+
+	list := []user{{"a", 10}, {"b", 20}, {"c", 30}}
+
+	age := func(item user) int {
+		return item.Age
+	}
+
+	fmt.Println(BinarySearchBy(list, 20, age))
+	fmt.Println(BinarySearchBy(list, 25, age))
+
+	// Output:
+	// 1 true
+	// 2 false
+}
+
+func ExampleMergeSorted() {
+	fmt.Println(MergeSorted(Compare[int], []int{1, 4, 7}, []int{2, 5}, []int{3, 6}))
+
+	// Output:
+	// [1 2 3 4 5 6 7]
+}
+
+func ExampleArgSort() {
+	list := []int{30, 10, 20}
+
+	fmt.Println(ArgSort(list, Compare[int]))
+
+	// Output:
+	// [1 2 0]
+}
+
+func ExampleRank() {
+	list := []int{30, 10, 20, 10}
+
+	fmt.Println(Rank(list, Compare[int]))
+
+	// Output:
+	// [4 1 3 1]
+}
+
+func ExampleReduce() {
+	list := []int{1, 2, 3, 4}
+
+	sum := Reduce(list, 0, func(acc int, index int, item int) int {
+		return acc + item
+	})
+
+	fmt.Println(sum)
+
+	// Output:
+	// 10
+}
+
+func ExampleReduceRight() {
+	list := []string{"a", "b", "c"}
+
+	s := ReduceRight(list, "", func(acc string, index int, item string) string {
+		return acc + item
+	})
+
+	fmt.Println(s)
+
+	// Output:
+	// cba
+}
+
+func ExampleScan() {
+	list := []int{1, 2, 3, 4}
+
+	result := Scan(list, 0, func(acc int, index int, item int) int {
+		return acc + item
+	})
+
+	fmt.Println(result)
+
+	// Output:
+	// [1 3 6 10]
+}
+
+func ExampleSum() {
+	fmt.Println(Sum([]int{1, 2, 3}), Sum([]float64{1.5, 2.5}))
+
+	// Output:
+	// 6 4
+}
+
+func ExampleProduct() {
+	fmt.Println(Product([]int{2, 3, 4}), Product([]int{}))
+
+	// Output:
+	// 24 1
+}
+
+func ExampleMean() {
+	fmt.Println(Mean([]int{1, 2, 3, 4}))
+	fmt.Println(Mean([]int{}))
+
+	// Output:
+	// 2.5 true
+	// 0 false
+}
+
+func ExampleMinOr() {
+	fmt.Println(MinOr([]int{3, 1, 2}, -1), MinOr([]int{}, -1))
+
+	// Output:
+	// 1 -1
+}
+
+func ExampleMaxOr() {
+	fmt.Println(MaxOr([]int{3, 1, 2}, -1), MaxOr([]int{}, -1))
+
+	// Output:
+	// 3 -1
+}
+
+func ExampleMinBy() {
+	type user struct {
+		Name string
+		Age  int
+	}
+
+	list := []user{{"a", 20}, {"b", 10}, {"c", 10}}
+
+	fmt.Println(MinBy(list, func(item user) int {
+		return item.Age
+	}))
+
+	// Output:
+	// {b 10} true
+}
+
+func ExampleMaxBy() {
+	type user struct {
+		Name string
+		Age  int
+	}
+
+	list := []user{{"a", 20}, {"b", 30}, {"c", 30}}
+
+	fmt.Println(MaxBy(list, func(item user) int {
+		return item.Age
+	}))
+
+	// Output:
+	// {b 30} true
+}
+
+func ExampleCountBy() {
+	list := []int{1, 2, 3, 4, 5}
+
+	result := CountBy(list, func(index int, item int) bool {
+		return item%2 == 0
+	})
+
+	fmt.Println(result)
+
+	// Output:
+	// map[false:3 true:2]
+}
+
+func ExampleParallelForEach() {
+	list := []int{1, 2, 3, 4, 5}
+
+	var sum int64
+
+	err := ParallelForEach(context.Background(), list, ParallelOptions{Workers: 2}, func(ctx context.Context, index int, item int) error {
+		atomic.AddInt64(&sum, int64(item))
+		return nil
+	})
+
+	fmt.Println(sum, err)
+
+	// Output:
+	// 15 <nil>
+}
+
+func ExampleParallelMap() {
+	list := []int{1, 2, 3}
+
+	result, err := ParallelMap(context.Background(), list, ParallelOptions{Workers: 2}, func(ctx context.Context, index int, item int) (string, error) {
+		return strconv.Itoa(item * 10), nil
+	})
+
+	fmt.Println(result, err)
+
+	// 收集所有错误
+	_, err = ParallelMap(context.Background(), list, ParallelOptions{CollectErrors: true}, func(ctx context.Context, index int, item int) (int, error) {
+		if item != 2 {
+			return 0, errors.New("failed")
+		}
+		return item, nil
+	})
+
+	var me *MultiError
+	if errors.As(err, &me) {
+		fmt.Println(me.Indexes())
+	}
+
+	// Output:
+	// [10 20 30] <nil>
+	// [0 2]
+}
+
+func ExampleParallelFilter() {
+	list := []int{1, 2, 3, 4}
+
+	result, err := ParallelFilter(context.Background(), list, ParallelOptions{}, func(ctx context.Context, index int, item int) (bool, error) {
+		return item%2 == 0, nil
+	})
+
+	fmt.Println(result, err)
+
+	// Output:
+	// [2 4] <nil>
+}
+
+func ExampleStreamOf() {
+	list := []int{1, 2, 3, 4, 5, 6}
+
+	result := StreamOf(list).
+		Filter(func(item int) bool {
+			return item%2 == 0
+		}).
+		Take(2).
+		Collect()
+
+	fmt.Println(result)
+
+	// Output:
+	// [2 4]
+}
+
+func ExampleStreamFromChan() {
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	ch <- 3
+	close(ch)
+
+	fmt.Println(StreamFromChan(ch).Skip(1).Collect())
+
+	// Output:
+	// [2 3]
+}
+
+func ExampleStreamGenerate() {
+	i := 0
+
+	// 无限的 Stream，只读取需要的元素
+	s := StreamGenerate(func() (int, bool) {
+		i++
+		return i * i, true
+	})
+
+	fmt.Println(s.Take(4).Collect())
+
+	// Output:
+	// [1 4 9 16]
+}
+
+func ExampleStreamMap() {
+	s := StreamMap(StreamOf([]int{1, 2, 3}), func(item int) string {
+		return "n" + strconv.Itoa(item)
+	})
+
+	fmt.Println(s.Collect())
+
+	// Output:
+	// [n1 n2 n3]
+}
+
+func ExampleStreamFlatMap() {
+	s := StreamFlatMap(StreamOf([]string{"ab", "c"}), func(item string) []byte {
+		return []byte(item)
+	})
+
+	fmt.Println(s.Count())
+
+	// Output:
+	// 3
+}
+
+func ExampleStreamChunk() {
+	s := StreamChunk(StreamOf([]int{1, 2, 3, 4, 5}), 2)
+
+	fmt.Println(s.Collect())
+
+	// Output:
+	// [[1 2] [3 4] [5]]
+}
+
+func ExampleStreamDistinct() {
+	s := StreamDistinct(StreamOf([]int{3, 1, 3, 2, 1}))
+
+	fmt.Println(s.Collect())
+
+	// Output:
+	// [3 1 2]
+}
+
+func ExampleStreamReduce() {
+	s := StreamOf([]int{1, 2, 3, 4})
+
+	sum := StreamReduce(s, 0, func(acc int, item int) int {
+		return acc + item
+	})
+
+	fmt.Println(sum)
+
+	// Output:
+	// 10
+}
+
+func ExampleZip2() {
+	ids := []int{1, 2, 3}
+	names := []string{"a", "b"}
+
+	result, err := Zip2(ids, names, ZipTruncate)
+	fmt.Println(result, err)
+
+	result, err = Zip2(ids, names, ZipPad)
+	fmt.Println(result, err)
+
+	_, err = Zip2(ids, names, ZipStrict)
+	fmt.Println(errors.Is(err, ErrLengthMismatch))
+
+	// Output:
+	// [{1 a} {2 b}] <nil>
+	// [{1 a} {2 b} {3 }] <nil>
+	// true
+}
+
+func ExampleZip3() {
+	result, err := Zip3([]int{1, 2}, []string{"a", "b"}, []bool{true, false}, ZipStrict)
+
+	fmt.Println(result, err)
+
+	// Output:
+	// [{1 a true} {2 b false}] <nil>
+}
+
+func ExampleZipWith() {
+	prices := []float64{1.5, 2}
+	counts := []int{2, 3}
+
+	result, err := ZipWith(prices, counts, ZipStrict, func(x float64, y int) float64 {
+		return x * float64(y)
+	})
+
+	fmt.Println(result, err)
+
+	// Output:
+	// [3 6] <nil>
+}
+
+func ExampleUnzip2() {
+	list := []Tuple2[int, string]{{1, "a"}, {2, "b"}}
+
+	ids, names := Unzip2(list)
+
+	fmt.Println(ids, names)
+
+	// Output:
+	// [1 2] [a b]
+}
+
+func ExampleUnzip3() {
+	list := []Tuple3[int, string, bool]{{1, "a", true}, {2, "b", false}}
+
+	ids, names, flags := Unzip3(list)
+
+	fmt.Println(ids, names, flags)
+
+	// Output:
+	// [1 2] [a b] [true false]
+}
+
+func ExampleCartesianProduct() {
+	fmt.Println(CartesianProduct([]string{"a", "b"}, []string{"x", "y"}))
+
+	// Output:
+	// [[a x] [a y] [b x] [b y]]
+}
+
+func ExampleCartesianProduct2() {
+	fmt.Println(CartesianProduct2([]string{"linux", "darwin"}, []int{32, 64}))
+
+	// Output:
+	// [{linux 32} {linux 64} {darwin 32} {darwin 64}]
+}
+
+func ExampleUniqueByKey() {
+	type user struct {
+		ID   int
+		Tags []string
+	}
+
+	list := []user{{1, []string{"a"}}, {2, nil}, {1, []string{"b"}}}
+
+	result := UniqueByKey(list, func(item user) int {
+		return item.ID
+	})
+
+	fmt.Println(result)
+
+	// Output:
+	// [{1 [a]} {2 []}]
+}
+
+func ExampleDifferenceBy() {
+	type user struct {
+		ID   int
+		Name string
+	}
+
+	list1 := []user{{1, "a"}, {2, "b"}}
+	list2 := []user{{2, "x"}, {3, "c"}}
+
+	result := DifferenceBy(list1, list2, func(item user) int {
+		return item.ID
+	})
+
+	fmt.Println(result)
+
+	// Output:
+	// [{1 a} {3 c}]
+}
+
+func ExampleIntersectionBy() {
+	type user struct {
+		ID   int
+		Name string
+	}
+
+	list1 := []user{{1, "a"}, {2, "b"}}
+	list2 := []user{{2, "x"}, {3, "c"}}
+
+	result := IntersectionBy(list1, list2, func(item user) int {
+		return item.ID
+	})
+
+	fmt.Println(result)
+
+	// Output:
+	// [{2 b}]
+}
+
+func ExampleUnionBy() {
+	type user struct {
+		ID   int
+		Name string
+	}
+
+	list1 := []user{{1, "a"}, {2, "b"}}
+	list2 := []user{{2, "x"}, {3, "c"}}
+
+	result := UnionBy(list1, list2, func(item user) int {
+		return item.ID
+	})
+
+	fmt.Println(result)
+
+	// Output:
+	// [{1 a} {2 b} {3 c}]
+}
+
+func ExampleMutualDifferenceBy() {
+	type user struct {
+		ID   int
+		Name string
+	}
+
+	list1 := []user{{1, "a"}, {2, "b"}}
+	list2 := []user{{2, "x"}, {3, "c"}}
+
+	left, right := MutualDifferenceBy(list1, list2, func(item user) int {
+		return item.ID
+	})
+
+	fmt.Println(left, right)
+
+	// Output:
+	// [{1 a}] [{3 c}]
+}
@@ -586,3 +586,193 @@ func TestInsertAt(t *testing.T) {
 	ass.Equal([]int{1, 2, 3, 4, 5, 6}, InsertAt(list, -1, 6))
 	ass.Equal([]int{1, 2, 3, 4, 5, 6}, InsertAt(list, 10, 6))
 }
+
+func TestChunk(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	list := []int{1, 2, 3, 4, 5}
+
+	ass.Equal([][]int{{1, 2}, {3, 4}, {5}}, Chunk(list, 2))
+	ass.Equal([][]int{{1, 2, 3, 4, 5}}, Chunk(list, 5))
+	ass.Equal([][]int{{1, 2, 3, 4, 5}}, Chunk(list, 10))
+	ass.Equal([][]int{}, Chunk([]int{}, 2))
+	ass.Equal([][]int{}, Chunk[int](nil, 2))
+
+	// 修改结果不会影响原切片和其他子切片
+	chunks := Chunk(list, 2)
+	chunks[0] = append(chunks[0], 100)
+	chunks[1][0] = 200
+
+	ass.Equal([]int{1, 2, 3, 4, 5}, list)
+	ass.Equal([]int{200, 4}, chunks[1])
+
+	ass.Equal([][]int{}, Chunk(list, 0))
+	ass.Equal([][]int{}, Chunk(list, -1))
+}
+
+func TestSlidingWindow(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	list := []int{1, 2, 3, 4, 5}
+
+	ass.Equal([][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}}, SlidingWindow(list, 3, 1))
+	ass.Equal([][]int{{1, 2}, {3, 4}}, SlidingWindow(list, 2, 2))
+	ass.Equal([][]int{{1}, {4}}, SlidingWindow(list, 1, 3))
+	ass.Equal([][]int{{1, 2, 3, 4, 5}}, SlidingWindow(list, 5, 1))
+	ass.Equal([][]int{}, SlidingWindow(list, 6, 1))
+	ass.Equal([][]int{}, SlidingWindow[int](nil, 1, 1))
+
+	windows := SlidingWindow(list, 2, 1)
+	windows[0][1] = 100
+
+	ass.Equal(2, windows[1][0])
+	ass.Equal([]int{1, 2, 3, 4, 5}, list)
+
+	ass.Equal([][]int{}, SlidingWindow(list, 0, 1))
+	ass.Equal([][]int{}, SlidingWindow(list, 1, 0))
+	ass.Equal([][]int{}, SlidingWindow(list, -1, -1))
+}
+
+func TestPartition(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	list := []int{1, 2, 3, 4, 5}
+
+	even, odd := Partition(list, func(index int, item int) bool {
+		return item%2 == 0
+	})
+
+	ass.Equal([]int{2, 4}, even)
+	ass.Equal([]int{1, 3, 5}, odd)
+
+	first, rest := Partition(list, func(index int, item int) bool {
+		return index == 0
+	})
+
+	ass.Equal([]int{1}, first)
+	ass.Equal([]int{2, 3, 4, 5}, rest)
+
+	matched, rest := Partition([]int{}, func(index int, item int) bool {
+		return true
+	})
+
+	ass.Equal([]int{}, matched)
+	ass.Equal([]int{}, rest)
+}
+
+func TestSplitBy(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	isZero := func(index int, item int) bool {
+		return item == 0
+	}
+
+	list := []int{1, 2, 0, 3, 0, 0, 4}
+
+	ass.Equal([][]int{{1, 2}, {3}, {}, {4}}, SplitBy(list, isZero))
+	ass.Equal([][]int{{}, {1}, {}}, SplitBy([]int{0, 1, 0}, isZero))
+	ass.Equal([][]int{{1, 2}}, SplitBy([]int{1, 2}, isZero))
+	ass.Equal([][]int{{}, {}}, SplitBy([]int{0}, isZero))
+	ass.Equal([][]int{}, SplitBy([]int{}, isZero))
+
+	parts := SplitBy(list, isZero)
+	parts[0] = append(parts[0], 100)
+
+	ass.Equal([]int{1, 2, 0, 3, 0, 0, 4}, list)
+}
+
+func TestGroupBy(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	list := []string{"b1", "a1", "b2", "c1", "a2"}
+
+	groups := GroupBy(list, func(index int, item string) string {
+		return item[:1]
+	})
+
+	ass.Equal([]string{"b", "a", "c"}, groups.Keys())
+	ass.Equal([][]string{{"b1", "b2"}, {"a1", "a2"}, {"c1"}}, groups.Values())
+
+	// 修改分组不会影响原切片
+	b, _ := groups.Get("b")
+	b[0] = "x"
+
+	ass.Equal([]string{"b1", "a1", "b2", "c1", "a2"}, list)
+
+	ass.Equal(0, GroupBy([]int{}, func(index int, item int) int {
+		return item
+	}).Len())
+}
+
+// 包含切片，不可比较
+type testRecord struct {
+	ID   int
+	Tags []string
+}
+
+func testRecordID(item testRecord) int {
+	return item.ID
+}
+
+func TestUniqueByKey(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	list := []testRecord{{1, []string{"a"}}, {2, nil}, {1, []string{"b"}}, {3, nil}, {2, []string{"c"}}}
+
+	ass.Equal([]testRecord{{1, []string{"a"}}, {2, nil}, {3, nil}}, UniqueByKey(list, testRecordID))
+	ass.Equal([]testRecord{}, UniqueByKey([]testRecord{}, testRecordID))
+}
+
+func TestDifferenceBy(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	list1 := []testRecord{{1, nil}, {2, []string{"a"}}, {3, nil}, {1, []string{"x"}}}
+	list2 := []testRecord{{2, []string{"b"}}, {4, nil}, {4, nil}}
+
+	ass.Equal([]testRecord{{1, nil}, {3, nil}, {1, []string{"x"}}, {4, nil}, {4, nil}}, DifferenceBy(list1, list2, testRecordID))
+	ass.Equal(list1, DifferenceBy(list1, nil, testRecordID))
+	ass.Equal([]testRecord{}, DifferenceBy(nil, nil, testRecordID))
+
+	left, right := MutualDifferenceBy(list1, list2, testRecordID)
+	ass.Equal([]testRecord{{1, nil}, {3, nil}, {1, []string{"x"}}}, left)
+	ass.Equal([]testRecord{{4, nil}, {4, nil}}, right)
+
+	// 返回的切片都是新分配的
+	left, right = MutualDifferenceBy(list1, []testRecord{}, testRecordID)
+	ass.Equal(list1, left)
+	ass.Equal([]testRecord{}, right)
+
+	left[0].ID = 100
+	ass.Equal(1, list1[0].ID)
+}
+
+func TestIntersectionBy(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	list1 := []testRecord{{1, nil}, {2, []string{"a"}}, {3, nil}, {2, []string{"x"}}}
+	list2 := []testRecord{{2, []string{"b"}}, {3, []string{"c"}}, {4, nil}}
+
+	ass.Equal([]testRecord{{2, []string{"a"}}, {3, nil}}, IntersectionBy(list1, list2, testRecordID))
+	ass.Equal([]testRecord{{2, []string{"b"}}, {3, []string{"c"}}}, IntersectionBy(list2, list1, testRecordID))
+	ass.Equal([]testRecord{}, IntersectionBy(list1, nil, testRecordID))
+}
+
+func TestUnionBy(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	list1 := []testRecord{{1, nil}, {2, []string{"a"}}, {1, []string{"x"}}}
+	list2 := []testRecord{{2, []string{"b"}}, {3, nil}}
+
+	ass.Equal([]testRecord{{1, nil}, {2, []string{"a"}}, {3, nil}}, UnionBy(list1, list2, testRecordID))
+	ass.Equal([]testRecord{{2, []string{"b"}}, {3, nil}, {1, nil}}, UnionBy(list2, list1, testRecordID))
+	ass.Equal([]testRecord{}, UnionBy(nil, nil, testRecordID))
+}
@@ -0,0 +1,214 @@
+package slicejez
+
+import (
+	"container/heap"
+	"sort"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Comparator 比较函数，a < b 时返回负数，a == b 时返回 0，a > b 时返回正数。
+type Comparator[T any] func(a, b T) int
+
+// Compare 比较两个值，可以直接作为 Comparator 使用。
+func Compare[T constraints.Ordered](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// CompareBy 返回按 key 函数的返回值升序比较的 Comparator。
+func CompareBy[T any, K constraints.Ordered](key func(item T) K) Comparator[T] {
+	return func(a, b T) int {
+		return Compare(key(a), key(b))
+	}
+}
+
+// ThenBy 返回组合的 Comparator，c 判断相等时再使用 next 比较。
+func (c Comparator[T]) ThenBy(next Comparator[T]) Comparator[T] {
+	return func(a, b T) int {
+		if r := c(a, b); r != 0 {
+			return r
+		}
+		return next(a, b)
+	}
+}
+
+// Desc 返回顺序相反的 Comparator。
+func (c Comparator[T]) Desc() Comparator[T] {
+	return func(a, b T) int {
+		return c(b, a)
+	}
+}
+
+// SortBy 按 key 函数的返回值升序排序，稳定排序，直接修改 list。
+func SortBy[T any, K constraints.Ordered](list []T, key func(item T) K) {
+	SortWith(list, CompareBy(key))
+}
+
+// SortWith 按 Comparator 排序，稳定排序，直接修改 list。
+func SortWith[T any](list []T, cmp Comparator[T]) {
+	sort.SliceStable(list, func(i, j int) bool {
+		return cmp(list[i], list[j]) < 0
+	})
+}
+
+// 保存元素及其在原切片中的索引，用于在比较结果相同时保持原有顺序
+type indexedItem[T any] struct {
+	item  T
+	index int
+}
+
+// 按 less 排序的堆，堆顶为排在最前面的元素
+type indexedHeap[T any] struct {
+	items []indexedItem[T]
+	less  func(a, b indexedItem[T]) bool
+}
+
+func (h *indexedHeap[T]) Len() int           { return len(h.items) }
+func (h *indexedHeap[T]) Less(i, j int) bool { return h.less(h.items[i], h.items[j]) }
+func (h *indexedHeap[T]) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *indexedHeap[T]) Push(x any)         { h.items = append(h.items, x.(indexedItem[T])) }
+func (h *indexedHeap[T]) Pop() any {
+	n := len(h.items) - 1
+	x := h.items[n]
+	h.items = h.items[:n]
+	return x
+}
+
+// 返回按 cmp 排在最前面的 k 个元素，比较结果相同时索引小的在前
+func firstK[T any](list []T, k int, cmp Comparator[T]) []T {
+	if k <= 0 || len(list) == 0 {
+		return []T{}
+	}
+
+	if k > len(list) {
+		k = len(list)
+	}
+
+	// a 排在 b 之后
+	after := func(a, b indexedItem[T]) bool {
+		if r := cmp(a.item, b.item); r != 0 {
+			return r > 0
+		}
+		return a.index > b.index
+	}
+
+	// 堆顶为排在最后、最先被淘汰的元素
+	h := &indexedHeap[T]{items: make([]indexedItem[T], 0, k), less: after}
+
+	for i, v := range list {
+		item := indexedItem[T]{item: v, index: i}
+
+		if h.Len() < k {
+			heap.Push(h, item)
+		} else if after(h.items[0], item) {
+			h.items[0] = item
+			heap.Fix(h, 0)
+		}
+	}
+
+	result := make([]T, h.Len())
+	for i := len(result) - 1; i >= 0; i-- {
+		result[i] = heap.Pop(h).(indexedItem[T]).item
+	}
+
+	return result
+}
+
+// TopK 返回最大的 k 个元素，按降序排列，相等的元素保持原有顺序，使用大小为 k 的堆，不会修改 list。
+func TopK[T any](list []T, k int, cmp Comparator[T]) []T {
+	return firstK(list, k, cmp.Desc())
+}
+
+// BottomK 返回最小的 k 个元素，按升序排列，相等的元素保持原有顺序，使用大小为 k 的堆，不会修改 list。
+func BottomK[T any](list []T, k int, cmp Comparator[T]) []T {
+	return firstK(list, k, cmp)
+}
+
+// BinarySearchBy 在按 key 升序排列的切片中二分查找 target，返回找到的索引或可以插入的位置，以及是否找到。
+//
+// 有多个相等的元素时返回第一个的索引。
+func BinarySearchBy[T any, K constraints.Ordered](list []T, target K, key func(item T) K) (int, bool) {
+	i := sort.Search(len(list), func(i int) bool {
+		return key(list[i]) >= target
+	})
+
+	return i, i < len(list) && key(list[i]) == target
+}
+
+// MergeSorted 合并多个已按 cmp 升序排列的切片，返回新的切片，相等的元素按 lists 中的顺序排列。
+func MergeSorted[T any](cmp Comparator[T], lists ...[]T) []T {
+	size := 0
+	for _, l := range lists {
+		size += len(l)
+	}
+
+	result := make([]T, 0, size)
+
+	// item 为 lists 中的索引，index 为该切片中的位置
+	h := &indexedHeap[int]{
+		less: func(a, b indexedItem[int]) bool {
+			if r := cmp(lists[a.item][a.index], lists[b.item][b.index]); r != 0 {
+				return r < 0
+			}
+			return a.item < b.item
+		},
+	}
+
+	for i, l := range lists {
+		if len(l) > 0 {
+			h.items = append(h.items, indexedItem[int]{item: i})
+		}
+	}
+
+	heap.Init(h)
+
+	for h.Len() > 0 {
+		top := h.items[0]
+		result = append(result, lists[top.item][top.index])
+
+		if top.index+1 < len(lists[top.item]) {
+			h.items[0].index++
+			heap.Fix(h, 0)
+		} else {
+			heap.Pop(h)
+		}
+	}
+
+	return result
+}
+
+// ArgSort 返回按 cmp 升序排列后各元素在 list 中的索引，稳定排序，不会修改 list。
+func ArgSort[T any](list []T, cmp Comparator[T]) []int {
+	indexes := make([]int, len(list))
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return cmp(list[indexes[i]], list[indexes[j]]) < 0
+	})
+
+	return indexes
+}
+
+// Rank 返回每个元素按 cmp 升序排列的名次，从 1 开始，相等的元素名次相同，并跳过后续名次，如 [1 2 2 4]。
+func Rank[T any](list []T, cmp Comparator[T]) []int {
+	indexes := ArgSort(list, cmp)
+	result := make([]int, len(list))
+
+	for i, idx := range indexes {
+		if i > 0 && cmp(list[indexes[i-1]], list[idx]) == 0 {
+			result[idx] = result[indexes[i-1]]
+		} else {
+			result[idx] = i + 1
+		}
+	}
+
+	return result
+}
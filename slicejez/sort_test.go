@@ -0,0 +1,157 @@
+package slicejez
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testUser struct {
+	Name string
+	Age  int
+}
+
+func TestComparator(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	ass.Equal(-1, Compare(1, 2))
+	ass.Equal(0, Compare("a", "a"))
+	ass.Equal(1, Compare(2.5, 1.5))
+
+	byAge := CompareBy(func(item testUser) int {
+		return item.Age
+	})
+	byName := CompareBy(func(item testUser) string {
+		return item.Name
+	})
+
+	a := testUser{Name: "a", Age: 20}
+	b := testUser{Name: "b", Age: 20}
+	c := testUser{Name: "c", Age: 10}
+
+	ass.Equal(0, byAge(a, b))
+	ass.Equal(1, byAge(a, c))
+	ass.Equal(-1, byAge.Desc()(a, c))
+	ass.Equal(-1, byAge.ThenBy(byName)(a, b))
+	ass.Equal(1, byAge.ThenBy(byName.Desc())(a, b))
+	ass.Equal(-1, byAge.Desc().ThenBy(byName)(a, c))
+}
+
+func TestSortBy(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	list := []testUser{{"d", 30}, {"b", 20}, {"c", 10}, {"a", 20}}
+
+	SortBy(list, func(item testUser) int {
+		return item.Age
+	})
+
+	// 稳定排序，b 在 a 之前
+	ass.Equal([]testUser{{"c", 10}, {"b", 20}, {"a", 20}, {"d", 30}}, list)
+
+	SortWith(list, CompareBy(func(item testUser) int {
+		return item.Age
+	}).Desc().ThenBy(CompareBy(func(item testUser) string {
+		return item.Name
+	})))
+
+	ass.Equal([]testUser{{"d", 30}, {"a", 20}, {"b", 20}, {"c", 10}}, list)
+
+	var empty []int
+	SortWith(empty, Compare[int])
+	ass.Nil(empty)
+}
+
+func TestTopK(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	list := []int{5, 1, 4, 2, 3, 5}
+
+	ass.Equal([]int{5, 5, 4}, TopK(list, 3, Compare[int]))
+	ass.Equal([]int{1, 2}, BottomK(list, 2, Compare[int]))
+	ass.Equal([]int{5, 5, 4, 3, 2, 1}, TopK(list, 10, Compare[int]))
+	ass.Equal([]int{}, TopK(list, 0, Compare[int]))
+	ass.Equal([]int{}, BottomK([]int{}, 2, Compare[int]))
+
+	// 不会修改原切片
+	ass.Equal([]int{5, 1, 4, 2, 3, 5}, list)
+
+	// 相等的元素保持原有顺序
+	users := []testUser{{"a", 20}, {"b", 30}, {"c", 20}, {"d", 30}, {"e", 10}}
+	byAge := CompareBy(func(item testUser) int {
+		return item.Age
+	})
+
+	ass.Equal([]testUser{{"b", 30}, {"d", 30}, {"a", 20}}, TopK(users, 3, byAge))
+	ass.Equal([]testUser{{"e", 10}, {"a", 20}, {"c", 20}}, BottomK(users, 3, byAge))
+}
+
+func TestBinarySearchBy(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	list := []testUser{{"a", 10}, {"b", 20}, {"c", 20}, {"d", 30}}
+	age := func(item testUser) int {
+		return item.Age
+	}
+
+	i, ok := BinarySearchBy(list, 20, age)
+	ass.True(ok)
+	ass.Equal(1, i)
+
+	i, ok = BinarySearchBy(list, 25, age)
+	ass.False(ok)
+	ass.Equal(3, i)
+
+	i, ok = BinarySearchBy(list, 40, age)
+	ass.False(ok)
+	ass.Equal(4, i)
+
+	i, ok = BinarySearchBy(list, 5, age)
+	ass.False(ok)
+	ass.Equal(0, i)
+
+	i, ok = BinarySearchBy([]testUser{}, 5, age)
+	ass.False(ok)
+	ass.Equal(0, i)
+}
+
+func TestMergeSorted(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	ass.Equal([]int{1, 2, 3, 4, 5, 6, 7}, MergeSorted(Compare[int], []int{1, 4, 7}, []int{2, 5}, nil, []int{3, 6}))
+	ass.Equal([]int{}, MergeSorted(Compare[int]))
+	ass.Equal([]int{1, 2}, MergeSorted(Compare[int], []int{1, 2}))
+
+	// 相等的元素按 lists 中的顺序排列
+	byAge := CompareBy(func(item testUser) int {
+		return item.Age
+	})
+
+	ass.Equal(
+		[]testUser{{"a", 10}, {"c", 10}, {"b", 20}, {"d", 20}},
+		MergeSorted(byAge, []testUser{{"a", 10}, {"b", 20}}, []testUser{{"c", 10}, {"d", 20}}),
+	)
+
+	// 降序
+	ass.Equal([]int{5, 4, 3, 1}, MergeSorted(Comparator[int](Compare[int]).Desc(), []int{5, 3}, []int{4, 1}))
+}
+
+func TestArgSort(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	list := []int{30, 10, 20, 10}
+
+	ass.Equal([]int{1, 3, 2, 0}, ArgSort(list, Compare[int]))
+	ass.Equal([]int{30, 10, 20, 10}, list)
+	ass.Equal([]int{}, ArgSort([]int{}, Compare[int]))
+
+	ass.Equal([]int{4, 1, 3, 1}, Rank(list, Compare[int]))
+	ass.Equal([]int{1, 3, 2, 3}, Rank(list, Comparator[int](Compare[int]).Desc()))
+	ass.Equal([]int{}, Rank([]int{}, Compare[int]))
+}
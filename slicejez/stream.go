@@ -0,0 +1,197 @@
+package slicejez
+
+// Stream 惰性的、拉取式的数据流，只有在调用 Collect、Reduce、First、Count 等终止操作时才会逐个读取元素，
+// 中间操作不会分配新的切片。
+//
+// 需要使用 StreamOf、StreamFromChan 或 StreamGenerate 创建，只能被消费一次，中间操作会消费原有的 Stream，不能并发使用。
+type Stream[T any] struct {
+	next func() (T, bool)
+}
+
+// StreamOf 从切片创建 Stream，不会复制 list，消费完成前不要修改 list。
+func StreamOf[T any](list []T) Stream[T] {
+	i := 0
+	return Stream[T]{next: func() (item T, ok bool) {
+		if i >= len(list) {
+			return
+		}
+		item = list[i]
+		i++
+		return item, true
+	}}
+}
+
+// StreamFromChan 从通道创建 Stream，通道关闭时结束。
+func StreamFromChan[T any](ch <-chan T) Stream[T] {
+	return Stream[T]{next: func() (T, bool) {
+		item, ok := <-ch
+		return item, ok
+	}}
+}
+
+// StreamGenerate 从生成函数创建 Stream，generator 返回 false 时结束，返回 false 后不会再被调用。
+func StreamGenerate[T any](generator func() (T, bool)) Stream[T] {
+	done := false
+	return Stream[T]{next: func() (item T, ok bool) {
+		if done {
+			return
+		}
+		if item, ok = generator(); !ok {
+			done = true
+		}
+		return
+	}}
+}
+
+// Next 读取下一个元素，没有更多元素时 ok 为 false。
+func (s Stream[T]) Next() (item T, ok bool) {
+	return s.next()
+}
+
+// Filter 只保留 predicate 返回 true 的元素。
+func (s Stream[T]) Filter(predicate func(item T) bool) Stream[T] {
+	return Stream[T]{next: func() (item T, ok bool) {
+		for item, ok = s.next(); ok; item, ok = s.next() {
+			if predicate(item) {
+				return
+			}
+		}
+		return
+	}}
+}
+
+// Take 最多读取 n 个元素，读取 n 个元素后不会再从上游读取。
+func (s Stream[T]) Take(n int) Stream[T] {
+	return Stream[T]{next: func() (item T, ok bool) {
+		if n <= 0 {
+			return
+		}
+		n--
+		return s.next()
+	}}
+}
+
+// Skip 跳过前 n 个元素。
+func (s Stream[T]) Skip(n int) Stream[T] {
+	return Stream[T]{next: func() (item T, ok bool) {
+		for ; n > 0; n-- {
+			if _, ok = s.next(); !ok {
+				n = 0
+				return
+			}
+		}
+		return s.next()
+	}}
+}
+
+// ForEach 遍历所有元素并为每个元素调用 iteratee 函数。
+func (s Stream[T]) ForEach(iteratee func(item T)) {
+	for item, ok := s.next(); ok; item, ok = s.next() {
+		iteratee(item)
+	}
+}
+
+// Collect 读取所有元素并返回切片，没有元素时返回空切片。
+func (s Stream[T]) Collect() []T {
+	result := make([]T, 0)
+	for item, ok := s.next(); ok; item, ok = s.next() {
+		result = append(result, item)
+	}
+
+	return result
+}
+
+// First 返回第一个元素，没有元素时 ok 为 false，只会从上游读取一个元素。
+func (s Stream[T]) First() (T, bool) {
+	return s.next()
+}
+
+// Count 读取所有元素并返回数量。
+func (s Stream[T]) Count() int {
+	n := 0
+	for _, ok := s.next(); ok; _, ok = s.next() {
+		n++
+	}
+
+	return n
+}
+
+// StreamMap 对每个元素调用 iteratee 函数，返回由结果组成的 Stream。
+func StreamMap[T, U any](s Stream[T], iteratee func(item T) U) Stream[U] {
+	return Stream[U]{next: func() (result U, ok bool) {
+		item, ok := s.next()
+		if !ok {
+			return
+		}
+		return iteratee(item), true
+	}}
+}
+
+// StreamFlatMap 对每个元素调用 iteratee 函数，并将返回的切片展开为一个 Stream。
+func StreamFlatMap[T, U any](s Stream[T], iteratee func(item T) []U) Stream[U] {
+	var (
+		buf []U
+		i   int
+	)
+
+	return Stream[U]{next: func() (result U, ok bool) {
+		for i >= len(buf) {
+			item, ok := s.next()
+			if !ok {
+				return result, false
+			}
+			buf, i = iteratee(item), 0
+		}
+
+		result = buf[i]
+		i++
+		return result, true
+	}}
+}
+
+// StreamChunk 将元素按 size 分组，最后一组的长度可能小于 size，每组都是新分配的切片，size <= 0 时返回空的 Stream。
+//
+// 每组按实际读取的元素数量增长，不会按 size 预先分配。
+func StreamChunk[T any](s Stream[T], size int) Stream[[]T] {
+	if size <= 0 {
+		return Stream[[]T]{next: func() (chunk []T, ok bool) {
+			return
+		}}
+	}
+
+	return Stream[[]T]{next: func() ([]T, bool) {
+		var chunk []T
+		for len(chunk) < size {
+			item, ok := s.next()
+			if !ok {
+				break
+			}
+			chunk = append(chunk, item)
+		}
+
+		return chunk, chunk != nil
+	}}
+}
+
+// StreamDistinct 去除重复的元素，保留第一次出现的元素。
+func StreamDistinct[T comparable](s Stream[T]) Stream[T] {
+	seen := make(map[T]struct{})
+
+	return s.Filter(func(item T) bool {
+		if _, ok := seen[item]; ok {
+			return false
+		}
+		seen[item] = struct{}{}
+		return true
+	})
+}
+
+// StreamReduce 读取所有元素，将 iteratee 的返回值作为下一次调用的 acc，返回最后的结果，没有元素时返回 initial。
+func StreamReduce[T, R any](s Stream[T], initial R, iteratee func(acc R, item T) R) R {
+	acc := initial
+	for item, ok := s.next(); ok; item, ok = s.next() {
+		acc = iteratee(acc, item)
+	}
+
+	return acc
+}
@@ -0,0 +1,235 @@
+package slicejez
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStream(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	list := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	result := StreamOf(list).
+		Filter(func(item int) bool {
+			return item%2 == 0
+		}).
+		Skip(1).
+		Take(3).
+		Collect()
+
+	ass.Equal([]int{4, 6, 8}, result)
+	ass.Equal([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, list)
+
+	ass.Equal([]int{}, StreamOf([]int{}).Collect())
+	ass.Equal([]int{}, StreamOf(list).Skip(20).Collect())
+	ass.Equal([]int{}, StreamOf(list).Take(0).Collect())
+	ass.Equal(10, StreamOf(list).Count())
+
+	first, ok := StreamOf(list).Skip(3).First()
+	ass.True(ok)
+	ass.Equal(4, first)
+
+	_, ok = StreamOf([]int{}).First()
+	ass.False(ok)
+
+	// Next 逐个读取
+	s := StreamOf([]int{1, 2})
+	v, ok := s.Next()
+	ass.Equal(1, v)
+	ass.True(ok)
+	v, ok = s.Next()
+	ass.Equal(2, v)
+	ass.True(ok)
+	_, ok = s.Next()
+	ass.False(ok)
+	_, ok = s.Next()
+	ass.False(ok)
+
+	sum := 0
+	StreamOf(list).ForEach(func(item int) {
+		sum += item
+	})
+	ass.Equal(55, sum)
+}
+
+func TestStream_Lazy(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	var pulled []int
+
+	i := 0
+	s := StreamGenerate(func() (int, bool) {
+		i++
+		pulled = append(pulled, i)
+		return i, true
+	})
+
+	// 无限的 Stream，只读取需要的元素
+	result := StreamMap(s.Filter(func(item int) bool {
+		return item%3 == 0
+	}), func(item int) string {
+		return strconv.Itoa(item)
+	}).Take(2).Collect()
+
+	ass.Equal([]string{"3", "6"}, result)
+	ass.Equal([]int{1, 2, 3, 4, 5, 6}, pulled)
+
+	// generator 返回 false 后不会再被调用
+	calls := 0
+	g := StreamGenerate(func() (int, bool) {
+		calls++
+		return calls, calls < 3
+	})
+
+	ass.Equal([]int{1, 2}, g.Collect())
+	_, ok := g.Next()
+	ass.False(ok)
+	ass.Equal(3, calls)
+}
+
+func TestStreamFromChan(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	ch := make(chan int)
+	go func() {
+		defer close(ch)
+		for i := 1; i <= 5; i++ {
+			ch <- i
+		}
+	}()
+
+	ass.Equal(15, StreamReduce(StreamFromChan(ch), 0, func(acc int, item int) int {
+		return acc + item
+	}))
+
+	// Take 不会多读取
+	ch2 := make(chan int, 3)
+	ch2 <- 1
+	ch2 <- 2
+	ch2 <- 3
+
+	ass.Equal([]int{1, 2}, StreamFromChan(ch2).Take(2).Collect())
+	ass.Equal(3, <-ch2)
+}
+
+func TestStreamFlatMap(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	s := StreamFlatMap(StreamOf([]int{0, 1, 2, 3}), func(item int) []int {
+		return Repeat(item, item)
+	})
+
+	ass.Equal([]int{1, 2, 2, 3, 3, 3}, s.Collect())
+	ass.Equal([]int{}, StreamFlatMap(StreamOf([]int{}), func(item int) []int {
+		return []int{item}
+	}).Collect())
+}
+
+func TestStreamChunk(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	ass.Equal([][]int{{1, 2}, {3, 4}, {5}}, StreamChunk(StreamOf([]int{1, 2, 3, 4, 5}), 2).Collect())
+	ass.Equal([][]int{{1, 2}}, StreamChunk(StreamOf([]int{1, 2}), 2).Collect())
+	ass.Equal([][]int{}, StreamChunk(StreamOf([]int{}), 2).Collect())
+
+	ass.Equal([][]int{}, StreamChunk(StreamOf([]int{1}), 0).Collect())
+	ass.Equal([][]int{}, StreamChunk(StreamOf([]int{1}), -1).Collect())
+
+	// 不会按 size 预先分配
+	ass.Equal([][]int{{1, 2}}, StreamChunk(StreamOf([]int{1, 2}), math.MaxInt).Collect())
+}
+
+func TestStreamDistinct(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	ass.Equal([]int{3, 1, 2}, StreamDistinct(StreamOf([]int{3, 1, 3, 2, 1})).Collect())
+	ass.Equal(2, StreamDistinct(StreamOf([]string{"a", "a", "b"})).Count())
+}
+
+const benchStreamSize = 1 << 16
+
+func benchStreamList() []int {
+	list := make([]int, benchStreamSize)
+	for i := range list {
+		list[i] = i % 1000
+	}
+	return list
+}
+
+// 每一步都分配新的切片
+func BenchmarkEagerPipeline(b *testing.B) {
+	list := benchStreamList()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		evens := Filter(list, func(index int, item int) bool {
+			return item%2 == 0
+		})
+		doubled := Map(evens, func(index int, item int) int {
+			return item * 2
+		})
+		_ = Unique(doubled)[:10]
+	}
+}
+
+func BenchmarkStreamPipeline(b *testing.B) {
+	list := benchStreamList()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		s := StreamOf(list).Filter(func(item int) bool {
+			return item%2 == 0
+		})
+		_ = StreamDistinct(StreamMap(s, func(item int) int {
+			return item * 2
+		})).Take(10).Collect()
+	}
+}
+
+func BenchmarkEagerSum(b *testing.B) {
+	list := benchStreamList()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		evens := Filter(list, func(index int, item int) bool {
+			return item%2 == 0
+		})
+		_ = Sum(Map(evens, func(index int, item int) int {
+			return item * 2
+		}))
+	}
+}
+
+func BenchmarkStreamSum(b *testing.B) {
+	list := benchStreamList()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		s := StreamOf(list).Filter(func(item int) bool {
+			return item%2 == 0
+		})
+		_ = StreamReduce(StreamMap(s, func(item int) int {
+			return item * 2
+		}), 0, func(acc int, item int) int {
+			return acc + item
+		})
+	}
+}
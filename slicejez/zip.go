@@ -0,0 +1,187 @@
+package slicejez
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrLengthMismatch 使用 ZipStrict 时切片长度不一致
+var ErrLengthMismatch = errors.New("slicejez: length mismatch")
+
+// ZipMode 切片长度不一致时的处理方式。
+type ZipMode int
+
+const (
+	// ZipTruncate 按最短的切片截断
+	ZipTruncate ZipMode = iota
+	// ZipPad 按最长的切片补齐，缺少的元素为零值
+	ZipPad
+	// ZipStrict 长度不一致时返回 ErrLengthMismatch
+	ZipStrict
+)
+
+// Tuple2 两个元素的元组。
+type Tuple2[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Tuple3 三个元素的元组。
+type Tuple3[A, B, C any] struct {
+	First  A
+	Second B
+	Third  C
+}
+
+// 根据 mode 返回结果的长度
+func zipLen(mode ZipMode, lens ...int) (int, error) {
+	min, max := lens[0], lens[0]
+	for _, l := range lens[1:] {
+		if l < min {
+			min = l
+		}
+		if l > max {
+			max = l
+		}
+	}
+
+	switch mode {
+	case ZipTruncate:
+		return min, nil
+	case ZipPad:
+		return max, nil
+	case ZipStrict:
+		if min != max {
+			return 0, fmt.Errorf("%w: %v", ErrLengthMismatch, lens)
+		}
+		return min, nil
+	}
+
+	return 0, fmt.Errorf("slicejez: unknown zip mode %d", mode)
+}
+
+// 返回索引 i 处的元素，超出范围时返回零值
+func elementAt[T any](list []T, i int) (item T) {
+	if i < len(list) {
+		item = list[i]
+	}
+	return
+}
+
+// Zip2 将两个切片按索引组合为 Tuple2 切片，长度不一致时按 mode 处理。
+func Zip2[A, B any](a []A, b []B, mode ZipMode) ([]Tuple2[A, B], error) {
+	return ZipWith(a, b, mode, func(x A, y B) Tuple2[A, B] {
+		return Tuple2[A, B]{First: x, Second: y}
+	})
+}
+
+// Zip3 将三个切片按索引组合为 Tuple3 切片，长度不一致时按 mode 处理。
+func Zip3[A, B, C any](a []A, b []B, c []C, mode ZipMode) ([]Tuple3[A, B, C], error) {
+	n, err := zipLen(mode, len(a), len(b), len(c))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Tuple3[A, B, C], n)
+	for i := range result {
+		result[i] = Tuple3[A, B, C]{First: elementAt(a, i), Second: elementAt(b, i), Third: elementAt(c, i)}
+	}
+
+	return result, nil
+}
+
+// ZipWith 按索引对两个切片的元素调用 iteratee 函数，返回由结果组成的切片，长度不一致时按 mode 处理。
+func ZipWith[A, B, R any](a []A, b []B, mode ZipMode, iteratee func(x A, y B) R) ([]R, error) {
+	n, err := zipLen(mode, len(a), len(b))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]R, n)
+	for i := range result {
+		result[i] = iteratee(elementAt(a, i), elementAt(b, i))
+	}
+
+	return result, nil
+}
+
+// Unzip2 将 Tuple2 切片拆分为两个切片，与 Zip2 相反。
+func Unzip2[A, B any](list []Tuple2[A, B]) ([]A, []B) {
+	a := make([]A, len(list))
+	b := make([]B, len(list))
+
+	for i, t := range list {
+		a[i], b[i] = t.First, t.Second
+	}
+
+	return a, b
+}
+
+// Unzip3 将 Tuple3 切片拆分为三个切片，与 Zip3 相反。
+func Unzip3[A, B, C any](list []Tuple3[A, B, C]) ([]A, []B, []C) {
+	a := make([]A, len(list))
+	b := make([]B, len(list))
+	c := make([]C, len(list))
+
+	for i, t := range list {
+		a[i], b[i], c[i] = t.First, t.Second, t.Third
+	}
+
+	return a, b, c
+}
+
+// CartesianProduct 返回多个切片的笛卡尔积，每个组合按 lists 的顺序排列，最后一个切片变化最快。
+//
+// 任意一个切片为空或没有参数时返回空切片。
+func CartesianProduct[T any](lists ...[]T) [][]T {
+	if len(lists) == 0 {
+		return [][]T{}
+	}
+
+	size := 1
+	for _, l := range lists {
+		size *= len(l)
+	}
+
+	result := make([][]T, 0, size)
+	if size == 0 {
+		return result
+	}
+
+	indexes := make([]int, len(lists))
+
+	for {
+		combo := make([]T, len(lists))
+		for i, l := range lists {
+			combo[i] = l[indexes[i]]
+		}
+		result = append(result, combo)
+
+		// 从最后一个切片开始进位
+		i := len(lists) - 1
+		for ; i >= 0; i-- {
+			indexes[i]++
+			if indexes[i] < len(lists[i]) {
+				break
+			}
+			indexes[i] = 0
+		}
+
+		if i < 0 {
+			return result
+		}
+	}
+}
+
+// CartesianProduct2 返回两个不同类型切片的笛卡尔积，b 变化最快。
+func CartesianProduct2[A, B any](a []A, b []B) []Tuple2[A, B] {
+	result := make([]Tuple2[A, B], 0, len(a)*len(b))
+
+	for _, x := range a {
+		for _, y := range b {
+			result = append(result, Tuple2[A, B]{First: x, Second: y})
+		}
+	}
+
+	return result
+}
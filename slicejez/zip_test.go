@@ -0,0 +1,117 @@
+package slicejez
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZip2(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	ids := []int{1, 2, 3}
+	names := []string{"a", "b"}
+
+	result, err := Zip2(ids, names, ZipTruncate)
+	ass.Nil(err)
+	ass.Equal([]Tuple2[int, string]{{1, "a"}, {2, "b"}}, result)
+
+	result, err = Zip2(ids, names, ZipPad)
+	ass.Nil(err)
+	ass.Equal([]Tuple2[int, string]{{1, "a"}, {2, "b"}, {3, ""}}, result)
+
+	result, err = Zip2(ids, names, ZipStrict)
+	ass.True(errors.Is(err, ErrLengthMismatch))
+	ass.Equal("slicejez: length mismatch: [3 2]", err.Error())
+	ass.Nil(result)
+
+	result, err = Zip2(ids, []string{"a", "b", "c"}, ZipStrict)
+	ass.Nil(err)
+	ass.Len(result, 3)
+
+	result, err = Zip2([]int{}, []string{}, ZipStrict)
+	ass.Nil(err)
+	ass.Equal([]Tuple2[int, string]{}, result)
+
+	_, err = Zip2(ids, names, ZipMode(9))
+	ass.Error(err)
+	ass.False(errors.Is(err, ErrLengthMismatch))
+
+	a, b := Unzip2(result)
+	ass.Equal([]int{}, a)
+	ass.Equal([]string{}, b)
+
+	result, _ = Zip2(ids, names, ZipPad)
+	a, b = Unzip2(result)
+	ass.Equal(ids, a)
+	ass.Equal([]string{"a", "b", ""}, b)
+}
+
+func TestZip3(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	result, err := Zip3([]int{1, 2}, []string{"a"}, []bool{true, false, true}, ZipPad)
+	ass.Nil(err)
+	ass.Equal([]Tuple3[int, string, bool]{{1, "a", true}, {2, "", false}, {0, "", true}}, result)
+
+	result, err = Zip3([]int{1, 2}, []string{"a"}, []bool{true, false, true}, ZipTruncate)
+	ass.Nil(err)
+	ass.Equal([]Tuple3[int, string, bool]{{1, "a", true}}, result)
+
+	_, err = Zip3([]int{1, 2}, []string{"a", "b"}, []bool{true}, ZipStrict)
+	ass.ErrorIs(err, ErrLengthMismatch)
+
+	a, b, c := Unzip3([]Tuple3[int, string, bool]{{1, "a", true}, {2, "b", false}})
+	ass.Equal([]int{1, 2}, a)
+	ass.Equal([]string{"a", "b"}, b)
+	ass.Equal([]bool{true, false}, c)
+}
+
+func TestZipWith(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	add := func(x int, y int) int {
+		return x + y
+	}
+
+	result, err := ZipWith([]int{1, 2, 3}, []int{10, 20}, ZipTruncate, add)
+	ass.Nil(err)
+	ass.Equal([]int{11, 22}, result)
+
+	result, err = ZipWith([]int{1, 2, 3}, []int{10, 20}, ZipPad, add)
+	ass.Nil(err)
+	ass.Equal([]int{11, 22, 3}, result)
+
+	result, err = ZipWith([]int{1}, nil, ZipStrict, add)
+	ass.ErrorIs(err, ErrLengthMismatch)
+	ass.Nil(result)
+}
+
+func TestCartesianProduct(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	ass.Equal([][]int{
+		{1, 3, 5}, {1, 3, 6}, {1, 4, 5}, {1, 4, 6},
+		{2, 3, 5}, {2, 3, 6}, {2, 4, 5}, {2, 4, 6},
+	}, CartesianProduct([]int{1, 2}, []int{3, 4}, []int{5, 6}))
+
+	ass.Equal([][]int{{1}, {2}}, CartesianProduct([]int{1, 2}))
+	ass.Equal([][]int{}, CartesianProduct([]int{1, 2}, []int{}))
+	ass.Equal([][]int{}, CartesianProduct[int]())
+
+	// 每个组合都是新分配的切片
+	result := CartesianProduct([]int{1, 2}, []int{3})
+	result[0][0] = 100
+	ass.Equal([]int{2, 3}, result[1])
+
+	ass.Equal(
+		[]Tuple2[string, int]{{"a", 1}, {"a", 2}, {"b", 1}, {"b", 2}},
+		CartesianProduct2([]string{"a", "b"}, []int{1, 2}),
+	)
+	ass.Equal([]Tuple2[string, int]{}, CartesianProduct2([]string{"a"}, []int{}))
+}